@@ -6,63 +6,111 @@
 // The document expaining this program and components is available at:
 //    "hawthornepresscom@gmail.com" under Documents.
 //
-package main
+
+package balance
 
 import (
 	"container/heap"
 	"fmt"
-	"math/rand"
+	"io"
+	"slices"
+	"sync/atomic"
 	"time"
 )
 
-// Number of Requester GO Routines and number of Worker GO Routines
+// Defaults: Requests buffered per Worker and number of Worker GO Routines
 const nRequester = 100
 const nWorker = 10
 
 // Request Structure
 type Request struct {
-	fn func() int // Work Function to call
-	c  chan int   // Reply Channel(Tells Request Function 'work done')
-}
-
-// "Request" Goroutine
-//    Infinite Loop - Wait ... Send Request ... Wait for done
-//  "Requester" Creates the Work Function, Done, and places in Work Channel
-func requester(work chan Request) {
-	c := make(chan int) // Make Reply Channel
-	for {               // Loop Forever
-		time.Sleep(time.Duration(rand.Int63n(nWorker * 2e9))) // Random Wait
-		work <- Request{op, c}                                // Push Request into "Work" Channel
-		<-c                                                   // Wait for "Done" Reply
-	}
-}
-
-// All the Work Requests instantiate the "op()" function below.
-// Simulation of some work: just sleep for a while and report how long.
-//
-func op() int { // Actual Simulated Work Function
-	n := rand.Int63n(1e9)
-	time.Sleep(time.Duration(nWorker * n)) // Sleep random amount
-	return int(n)                          // Return time slept(value not used)
+	fn   func() int        // Work Function to call
+	c    chan int          // Reply Channel(Tells Request Function 'work done')
+	gone <-chan struct{}   // Closed if the Submitter stops waiting (nil = Never)
+	cost int               // Estimated Load of the Request (0 = Default of 1)
+	h    *Handle           // Submitter's Handle, told the chosen Worker (nil = None)
+	meta map[string]string // Caller's Labels (tenant, key, ...) for InFlight
+	seq  int64             // Request Number, set on Dispatch (Names it in the Done Report)
+	prio int               // Priority (Higher is more Urgent, 0 = Normal)
+	pre  *attempt          // Preemptible Run (nil = Not Preemptible)
+	twin *Handle           // Hedge: the Original's Handle (its Worker is Avoided)
+	in   time.Time         // When the Balance Loop took it in (zero = Bypassed the Intake)
 }
 
 // Worker Structure: Holds Requests, Index into Pool Queue, and Job Count
 type Worker struct {
 	i        int          // Index into the Pool Structure
+	id       int          // Stable Worker ID (Never changes, unlike i)
 	requests chan Request // Worker Request Value
 	pending  int          // Pending Job Count Value
+	jobs     []job        // Pending Jobs in Dispatch order (Oldest first)
+	last     int64        // Seq of the last Request Dispatched (Tie-break)
+	retired  bool         // Removed from the Pool (Finishing its last Jobs)
+	stuck    bool         // Evicted as Stuck (its Jobs are off the Books)
+	cooldown atomic.Int64 // Rest after each Job (time.Duration, 0 = None)
+	weight   int          // Relative Capacity (for Weighted Strategies)
+	queued   Timing       // Queue Times of Completed Jobs
+	service  Timing       // Service Times of Completed Jobs
+	served   int64        // Jobs Completed since it was Created (Loop only)
+	born     time.Time    // When the Worker was Created (Uptime)
+	opts     *options     // Balancer's Run-time Options (Shared by all Workers)
+	begin    chan *Worker // Start Channel (Worker reports it began a Job)
+	exec     Executor     // How this Worker runs a Job (nil = Call it)
+}
+
+// Options Structure: Settings that may change while the Balancer runs.
+// Workers and submitters read them without going through the Balance
+// Loop, so every field is atomic (or, like the name, never changes).
+type options struct {
+	name      string        // Balancer Name, for Logs and Stats (Fixed)
+	policy    atomic.Int32  // PanicPolicy for Work Functions
+	replyBuf  atomic.Int32  // Buffer of Reply Channels made by the Balancer
+	replyWait atomic.Int64  // Longest a Worker waits to deliver (0 = Forever)
+	tie       atomic.Int32  // TieBreak for Equal Loads (Fixed once Running)
+	slots     chan struct{} // Execution Slots (nil = No Cap; Fixed once Running)
+	active    atomic.Int64  // Work Functions Executing right now
+	logger    Logger        // Diagnostics (nil = Silent; Fixed once Running)
+}
+
+// Job Structure: The Balance Loop's record of one dispatched Request
+type job struct {
+	seq     int64             // Request Number (Order of Dispatch)
+	in      time.Time         // When the Balance Loop took it in
+	sent    time.Time         // When it was Dispatched
+	started time.Time         // When the Worker began it (zero = Queued)
+	cost    int               // Load it added to the Worker's pending count
+	meta    map[string]string // Caller's Labels
+	h       *Handle           // Submitter's Handle (nil = None)
+	prio    int               // Priority of the Request
+	pre     *attempt          // Preemptible Run (nil = Not Preemptible)
+	req     Request           // The Request itself, if Preemptible (to Re-queue)
 }
 
 // The "work" Method places executes the worker function and waits till
-// completed and sends the *Worker value to the done channel
-func (w *Worker) work(done chan *Worker) {
-	for {
-		req := <-w.requests // Get Request Channel
-		req.c <- req.fn()   // Send Function Call to Channel
-		done <- w           // Wait for Function to complete
+// completed and sends the *Worker value (with the Request Number) to the
+// done channel
+// A Worker with a cooldown rests BEFORE reporting done, so its pending
+// count stays raised while cooling and the heap routes new work elsewhere.
+func (w *Worker) work(done chan finished) {
+	for req := range w.requests { // Get Request Channel (Closed = Retired)
+		w.acquire()         // Wait for an Execution Slot (if Capped)
+		w.begin <- w        // Report the Job is Running
+		v := w.call(req.fn) // Call the Work Function
+		w.release()         // Free the Slot
+		w.reply(req, v)     // Send Function Call to Channel
+		if d := time.Duration(w.cooldown.Load()); d > 0 {
+			time.Sleep(d) // Cooldown: Worker not yet available
+		}
+		done <- finished{w, req.seq} // Wait for Function to complete
 	}
 }
 
+// Finished Structure: A Worker's Done Report for one Request
+type finished struct {
+	w   *Worker // Worker that ran it
+	seq int64   // Request Number (from the Request)
+}
+
 // Pool Slice (Implements Priority Queue via HEAP Interface!)
 //
 type Pool []*Worker // Create Slice of Pointers to Worker Structures
@@ -72,8 +120,16 @@ type Pool []*Worker // Create Slice of Pointers to Worker Structures
 //
 func (p Pool) Len() int { return len(p) } // Return length of Pool
 
+// Less compares pending load per unit of weight (pending/weight, cross
+// multiplied to stay in integers), so a Worker of weight 4 takes four
+// times the pending load before it stops being the least loaded. With the
+// default weight of 1 this is the plain pending count.
 func (p Pool) Less(i, j int) bool {
-	return p[i].pending < p[j].pending // Return Compare of pending values
+	li, lj := p[i].pending*p[j].weight, p[j].pending*p[i].weight // Normalized Loads
+	if li == lj && TieBreak(p[i].opts.tie.Load()) == TieLeastRecent {
+		return p[i].last < p[j].last // Equal Load: Longest Unused first
+	}
+	return li < lj // Return Compare of normalized pending values
 }
 
 func (p *Pool) Swap(i, j int) {
@@ -84,13 +140,13 @@ func (p *Pool) Swap(i, j int) {
 }
 
 func (p *Pool) Push(x interface{}) {
-	a := *p          // Get base of Pool Structure
-	n := len(a)      // Len of input Slice
-	a = a[0 : n+1]   // Create New Element at end+1 of slice
-	w := x.(*Worker) // w now equal *Worker Parameter
-	a[n] = w         // Put Worker in new slot
-	w.i = n          // Worker.i = position in Pool!
-	*p = a           // Update Pool Slice!
+	a := *p            // Get base of Pool Structure
+	n := len(a)        // Len of input Slice
+	a = append(a, nil) // Create New Element at end+1 of slice
+	w := x.(*Worker)   // w now equal *Worker Parameter
+	a[n] = w           // Put Worker in new slot
+	w.i = n            // Worker.i = position in Pool!
+	*p = a             // Update Pool Slice!
 }
 
 func (p *Pool) Pop() interface{} {
@@ -101,84 +157,514 @@ func (p *Pool) Pop() interface{} {
 	return w             // Return Last Pool Value
 }
 
+// The following routines implement the Queue Interface on the Heap.
+//
+func (p *Pool) Add(w *Worker)  { heap.Push(p, w) } // PUSH Worker into Heap
+func (p *Pool) Least() *Worker { return (*p)[0] }  // Root = Lightest Load
+
+// Drop: Removes the Worker at its index - if it is really there. A stale
+// index (-1 after a Pop, or a Worker already dropped) leaves the Heap
+// alone instead of panicking or removing some other Worker, and is logged.
+func (p *Pool) Drop(w *Worker) {
+	if !p.holds(w) {
+		w.stale("drop")
+		return
+	}
+	heap.Remove(p, w.i) // Remove Worker at its index
+}
+
+// Holds: Whether the Worker's index points at the Worker itself.
+func (p Pool) holds(w *Worker) bool {
+	return w.i >= 0 && w.i < len(p) && p[w.i] == w
+}
+
+// Stale: Logs an operation on a Worker its Queue does not hold - a
+// bookkeeping slip that is survived, but should not go unnoticed.
+func (w *Worker) stale(op string) {
+	if w.opts != nil {
+		w.opts.logf("worker %d: %s with stale index %d, ignored", w.id, op, w.i)
+	}
+}
+
+// Spare: Whether the Worker's buffer has a free slot, so a dispatch to
+// it does not block. Only the Balance Loop sends to the buffer, so the
+// answer holds until it does; the Worker only ever frees slots.
+func (w *Worker) spare() bool {
+	return len(w.requests) < cap(w.requests)
+}
+
+// Adjust: The Worker stays in the Heap; only its pending value changed, so
+// moving it up or down from where it is restores the order - one O(log n)
+// pass instead of a Remove and a Push.
+func (p *Pool) Adjust(w *Worker) {
+	if !p.holds(w) {
+		w.stale("adjust") // Not (or no longer) in the Heap
+		return
+	}
+	heap.Fix(p, w.i) // Re-position Item with its new pending value
+}
+
+func (p *Pool) Workers() []*Worker { return *p } // Heap order, not ID order
+
 type Balancer struct {
-	pool Pool
-	done chan *Worker
+	pool    Queue                 // Priority Queue of Workers (Least Loaded first)
+	workers []*Worker             // Workers in the Pool by Stable ID (Loop only)
+	done    chan finished         // Completion Channel (Workers report done)
+	begin   chan *Worker          // Start Channel (Workers report a Job running)
+	ctl     chan func()           // Control Channel (Run inside the Balance Loop)
+	work    chan Request          // Intake "Work" Channel (Requests to Dispatch)
+	monitor *varianceMonitor      // Optional Imbalance Alarm (nil = Off)
+	stats   atomic.Pointer[Stats] // Latest Snapshot (Published by Balance Loop)
+	pending int                   // Total Pending Count across the Pool
+	ready   readiness             // Backpressure Signal for Producers
+	opts    options               // Run-time Options (Read by Workers & Submitters)
+	strat   Strategy              // Worker Selection Policy
+	later   timetable             // Scheduled Requests (Not yet Pending)
+	retry   deferral              // Deferred Requests (Lower Priority Intake)
+	max     int                   // Capacity: Most Workers the Pool may hold (0 = No Limit)
+	min     int                   // Fewest Workers the Pool may shrink to (at least 1)
+	depth   int                   // Per-Worker Buffer Size (Requests)
+	loop    Timing                // Balance Loop Event Handling Times
+	rate    meter                 // Recent Dispatch & Completion Counts
+	sched   Scheduler             // Intake Ordering (Which Request goes Next)
+	mem     memGate               // Result Memory Accounting (SubmitSized)
+	preempt bool                  // Urgent Requests may Preempt (SetPreemption)
+	srcs    sources               // Ordered Streams by Source ID
+	ceiling int                   // Pending Cap of the Least Loaded Worker (0 = None)
+	reject  bool                  // Full Pool Refuses instead of Blocking
+	sink    func(*Stats)          // Stats Sink: print hands it Snapshots (nil = Quiet)
+	every   time.Duration         // Least Time between two Sink calls (0 = Every Event)
+	printed time.Time             // Last Sink call
+	hang    time.Duration         // Running this long Evicts a Worker (0 = Never)
+	limit   throttle              // Dispatch Rate Limit (see SetRateLimit)
+	retries Retry                 // SubmitErr Retry Policy (see SetRetry)
+
+	seq        int64                  // Requests Dispatched so far (Request Numbers)
+	ids        int                    // Next Stable Worker ID (for Workers added later)
+	totals     Totals                 // Cumulative Counters (Reset by ResetStats)
+	peak       int                    // Highest Total Pending (Reset by ResetStats)
+	worst      time.Duration          // Slowest Request seen (Reset by ResetStats)
+	born       time.Time              // When the Balancer was Created (Uptime)
+	rejected   atomic.Int64           // Requests Refused Admission (Since Reset)
+	onComplete func(CompletionInfo)   // Completion Hook (nil = None)
+	onTrace    func(TraceEvent)       // Trace Recorder (nil = None)
+	onRoot     func(oldID, newID int) // Root Change Hook (nil = None)
+	onBusy     func(id int)           // Worker leaves Idle Hook (nil = None)
+	onIdle     func(id int)           // Worker becomes Idle Hook (nil = None)
+	events     []chan CompletionEvent // Completion Events, one Channel per Sink
+	pulse      *time.Ticker           // Heartbeat Tick (keeps an idle loop beating)
+	beat       atomic.Int64           // Last Loop Iteration (Unix Nanoseconds)
+	paused     bool                   // Dispatching Suspended (see Pause)
+	backlog    []Request              // Taken in, not yet Placed on a Worker (see schedule)
+	stalled    bool                   // Next Request's Worker has a Full Buffer (Intake held)
+	holds      int                    // Drain calls Holding the Intake
+	quiet      []chan struct{}        // Drain Waiters (Closed once Idle)
+	stopped    bool                   // Closed: the Loop exits (see Close)
+	sealed     bool                   // Intake Channel Closed (see Close)
+
+	roster atomic.Pointer[[]*Worker] // Copy of "workers" for use outside the Loop
+	state  atomic.Int32              // Lifecycle State (see State)
+	final  atomic.Pointer[Report]    // Report taken by Close (nil = Open)
+	lost   atomic.Int64              // Completion Events Dropped (Emitter behind)
+
+	closing chan struct{} // Closed once Close seals the Intake
+	exited  chan struct{} // Closed once the Balance Loop has exited
+	sending atomic.Int64  // Submitters handing in a Request right now (see hand)
 }
 
 // Create Pool and Start Work Goroutines
 func NewBalancer() *Balancer {
-	done := make(chan *Worker, nWorker)
-	b := &Balancer{make(Pool, 0, nWorker), done}
-	for i := 0; i < nWorker; i++ { // For Each Worker
+	return NewNamedBalancer("")
+}
+
+// New: Creates a Balancer and starts its Balance Loop, ready for Submit.
+// Use NewBalancer and Start instead to configure the Balancer first.
+func New() *Balancer {
+	b := NewBalancer()
+	b.Start()
+	return b
+}
+
+// Start: Launches the Balance Loop on its own goroutine. Call it once,
+// after any setup that must happen before the loop is started.
+func (b *Balancer) Start() {
+	go b.balance()
+}
+
+// Create a Named Pool: The name labels the Balancer's logs and Stats, so
+// several Balancers in one process ("image-resize-pool", "thumbnail-pool")
+// can be told apart.
+func NewNamedBalancer(name string) *Balancer {
+	return newBalancer(name, nWorker, nRequester)
+}
+
+// NewBalancer Body: A Pool of "workers" Workers, each buffering up to
+// "depth" requests. The callers make sure both are at least 1.
+func newBalancer(name string, workers, depth int) *Balancer {
+	pool := make(Pool, 0, workers) // Default Queue: the exact Heap
+	b := &Balancer{
+		pool:  &pool,
+		done:  make(chan finished, workers),
+		begin: make(chan *Worker, workers),
+		work:  make(chan Request), // Create the "Work" Channel
+		ctl:   make(chan func()),
+		strat: leastLoaded{},
+		sched: &fifo{},
+		min:   1,
+		ids:   workers, // IDs 0..workers-1 Taken below
+		depth: depth,
+	}
+	b.opts.name = name
+	b.born = time.Now()
+	b.closing = make(chan struct{}) // Open until Close
+	b.exited = make(chan struct{})
+	for i := 0; i < workers; i++ { // For Each Worker
 		// Create a Worker Structure and Point to it
-		w := &Worker{requests: make(chan Request, nRequester)}
-		heap.Push(&b.pool, w) // PUSH Struture into Queue
-		go w.work(b.done)     // Start work processing routine
+		w := &Worker{id: i, requests: make(chan Request, depth), weight: 1, opts: &b.opts, begin: b.begin, born: b.born}
+		b.pool.Add(w)                    // PUSH Struture into Queue
+		b.workers = append(b.workers, w) // Remember Worker by ID
+		go w.work(b.done)                // Start work processing routine
+	}
+	b.enroll() // Publish the Roster
+	b.mem.cond.L = &b.mem.mu
+	b.pulse = time.NewTicker(heartbeat)
+	b.later.init() // No Scheduled Requests yet
+	b.retry.init() // No Deferred Requests yet
+	b.limit.init() // No Rate Limit yet
+	b.ready.init() // Initially Ready: No Work Pending
+	b.rate.init(throughputWindow)
+	b.publish() // Initial Statistics Snapshot
+	return b    // Return pointer to Balancer Structure
+}
+
+// SetCooldown: Sets a per-worker rest period after each completed job,
+// modelling resources that need a break between jobs (rate-limited APIs,
+// hardware). Reports false if no Worker has the given ID.
+// Safe to call at any time; it takes effect from the next completion.
+func (b *Balancer) SetCooldown(id int, d time.Duration) bool {
+	w := b.find(id)
+	if w == nil {
+		return false
+	}
+	w.cooldown.Store(int64(d))
+	return true
+}
+
+// SetWeight: Sets a Worker's relative capacity (default 1), used by the
+// weighted Strategies and the Heap ordering. Reports false if no Worker has the given ID or the
+// weight is below 1. Must be called before the Balance Loop is started.
+func (b *Balancer) SetWeight(id int, weight int) bool {
+	w := b.find(id)
+	if w == nil || weight < 1 {
+		return false
+	}
+	w.weight = weight
+	return true
+}
+
+// SetWorkerWeight: Changes a running Worker's weight, e.g. when its
+// backing machine is throttled, without removing and re-adding it. The
+// change is made inside the Balance Loop, so the next dispatch already
+// sees it, and the Worker is re-positioned in the Queue in case the
+// ordering takes weights into account. Reports false if no Worker has the
+// given ID or the weight is below 1. The Balance Loop must be running.
+func (b *Balancer) SetWorkerWeight(id int, weight int) bool {
+	if weight < 1 {
+		return false
 	}
-	return b // Return pointer to Balancer Structure
+	ok := false
+	b.do(func() {
+		if w := b.lookup(id); w != nil {
+			w.weight = weight
+			b.pool.Adjust(w) // Re-position for the new Capacity
+			ok = true
+		}
+	})
+	return ok
 }
 
 // Print Statistics:
-//     This Function prints balancing statistics each time a worker
-//     completes a task. It prints out the number of the pending requests per
-//     worker and the average pending requests and their variance.
+//     This Function hands the balancing statistics to the Stats Sink each
+//     time a worker completes a task (or a request is dispatched), at most
+//     once per Stats Interval. Without a Sink the Balancer is quiet.
 //
 func (b *Balancer) print() {
-	sum := 0
-	sumsq := 0
-	for _, w := range b.pool { //Loop thru the Pool
-		fmt.Printf("%d ", w.pending) // Print Pending Count
-		sum += w.pending             // Compute Intermediates
-		sumsq += w.pending * w.pending
+	if b.sink == nil {
+		return // Quiet (the Library Default)
+	}
+	now := time.Now()
+	if b.every > 0 && now.Sub(b.printed) < b.every {
+		return // Handed over Recently
+	}
+	b.printed = now
+	b.sink(b.stats.Load()) // The Snapshot just Published
+}
+
+// WriteStats: The classic printout - one line with the number of the
+// pending requests per worker and the average pending requests and
+// their variance.
+func writeStats(out io.Writer, s *Stats) {
+	if s.Name != "" {
+		fmt.Fprintf(out, "%s: ", s.Name) // Label the Line
+	}
+	for _, w := range s.Workers { //Loop thru the Pool (Fixed Columns)
+		fmt.Fprintf(out, "%d ", w.Pending) // Print Pending Count
+	}
+	// Print Average and Variance of Pending Counts
+	fmt.Fprintf(out, " %.2f %.2f\n", s.Average, s.Variance)
+}
+
+// SetOutput: Installs a Stats Sink writing the classic printout to "w" -
+// one line of pending counts, average and variance after every event, the
+// running picture the demo shows. A nil Writer removes the Sink.
+// Must be called before the Balance Loop is started.
+func (b *Balancer) SetOutput(w io.Writer) {
+	if w == nil {
+		b.sink = nil
+		return
+	}
+	b.SetStatsSink(func(s *Stats) { writeStats(w, s) })
+}
+
+// SetStatsSink: Installs a Stats Sink, called with the new Snapshot after
+// each event (see SetStatsInterval) - for logging, metrics or a custom
+// printout. It runs on the Balance Loop, so it must be quick; nil (the
+// default) keeps the Balancer quiet. Must be called before the Balance
+// Loop is started.
+func (b *Balancer) SetStatsSink(fn func(*Stats)) {
+	b.sink = fn
+}
+
+// SetStatsInterval: Hands the Stats Sink a Snapshot at most once per "d"
+// instead of after every dispatch and completion (0, the default, means
+// every event). Quiet stretches send nothing, so the last Snapshot the
+// Sink saw may be older than "d". Must be called before the Balance Loop
+// is started.
+func (b *Balancer) SetStatsInterval(d time.Duration) {
+	b.every = d
+}
+
+// Spread: Computes the Average and Variance of the Pending Counts, each
+// taken per unit of weight, so a weighted Pool balanced by capacity shows
+// no variance (with all weights 1 these are the plain counts).
+// Called only from the Balance Loop, so the Pool is read safely.
+func (b *Balancer) spread() (avg, variance float64) {
+	sum := 0.0
+	sumsq := 0.0
+	for _, w := range b.pool.Workers() { //Loop thru the Pool
+		load := float64(w.pending) / float64(w.weight) // Normalized Load
+		sum += load                                    // Compute Intermediates
+		sumsq += load * load
 	}
-	// Compute and Print Average and Variance of Pending Counts
-	avg := float64(sum) / float64(len(b.pool))
-	variance := float64(sumsq)/float64(len(b.pool)) - avg*avg
-	fmt.Printf(" %.2f %.2f\n", avg, variance)
+	avg = sum / float64(b.pool.Len())
+	variance = sumsq/float64(b.pool.Len()) - avg*avg
+	return avg, variance
 }
 
 // Balance Loop Processing
-func (b *Balancer) balance(work chan Request) {
-	for { // Infinite Loop
+func (b *Balancer) balance() {
+	var start time.Time // When the current Event was Received
+	for {               // Infinite Loop
 		select { // Select on Channel
-		case req := <-work: // Dispatch Requests
-			b.dispatch(req)
-		case w := <-b.done: // Process Completions
-			b.completed(w)
+		case req := <-b.intake(): // Dispatch Requests (via the Scheduler)
+			start = time.Now()
+			req.in = start // Submission Time (CompletionEvent)
+			if !b.turnedAway(req) {
+				b.sched.Push(req)
+			}
+		case f := <-b.done: // Process Completions
+			start = time.Now()
+			b.catchUp() // Starts are reported before their Completions
+			b.completed(f.w, f.seq)
+		case d := <-b.later.in: // Hold Scheduled Requests
+			start = time.Now()
+			heap.Push(&b.later.queue, d)
+		case <-b.later.alarm.C: // Release Requests that are Due
+			start = time.Now()
+			b.release()
+		case d := <-b.retry.in: // Hold Deferred Requests
+			start = time.Now()
+			heap.Push(&b.retry.queue, d)
+		case <-b.retry.alarm.C: // Deferred Requests are Due
+			start = time.Now()
+			b.ripen()
+		case <-b.retry.waiting(): // Serve a Due Deferred Request
+			start = time.Now()
+			b.serveDeferred()
+		case <-b.limit.alarm.C: // Rate Limit allows the next Dispatch
+			start = time.Now()
+		case fn := <-b.ctl: // Run a Control Operation
+			start = time.Now()
+			fn()
+			if b.stopped {
+				return // Closed: Leave the Loop
+			}
+		case w := <-b.begin: // Mark a Job as Running (no load change)
+			b.begun(w)
+			b.heartbeat()
+			continue
+		case <-b.pulse.C: // Idle: Just show we are alive
+			start = time.Now()
+			if b.unstick()+b.schedule() == 0 { // Nothing Evicted or Released
+				b.check() // A Stall shows no Traffic at all
+				b.heartbeat()
+				continue
+			}
 		}
-		b.print() // Print Statistics
+		b.schedule()                     // Dispatch what the Scheduler Releases
+		b.later.rearm()                  // Alarm for the next Scheduled Request
+		b.retry.rearm()                  // Alarm for the next Deferred Request
+		b.limit.rearm()                  // Alarm for the next allowed Dispatch
+		b.check()                        // Check for Chronic Imbalance
+		b.loop.record(time.Since(start)) // Time spent Handling the Event
+		b.publish()                      // Publish Statistics Snapshot
+		b.print()                        // Print Statistics (to the Sink)
+		b.signal()                       // Update Readiness Signal
+		b.settle()                       // Wake Drain once Idle
+		b.heartbeat()                    // Loop is Responsive
 	}
 }
 
 // These functions (dispatch and Completed):
-// Dispatch finds Worker with the Lightest Load and sends it the request.
-func (b *Balancer) dispatch(req Request) {
-	w := heap.Pop(&b.pool).(*Worker) // Get Item with least/equal low value
-	w.requests <- req                // Update Request Buffer
-	w.pending++                      // Advance Pending Count (+1)
-	heap.Push(&b.pool, w)            // Update Value in Heap!
-}
-
-// Completed: When a request is completed it is removed from the Pool,
-// Then pushes the worker back into the Pool with a updated Pending Value.
-func (b *Balancer) completed(w *Worker) {
-	w.pending--               // Update Pending Value (-1)
-	heap.Remove(&b.pool, w.i) // Remove Item at work index in pool
-	heap.Push(&b.pool, w)     // Push back Item with a new pending value
-}
-
-// The main function:
-// - creates Work Channel
-// - Create and start Request Goroutines
-// - Create Worker Pool and Start Worker Go Routines
-// - launch balancer Loop
-func main() {
-	work := make(chan Request) // Create the "Work" Channel
-	for i := 0; i < nRequester; i++ {
-		go requester(work) // Create and start request Goroutines
-	}
-	b := NewBalancer() // Create Worker Pool & Start Workers Goroutines
-
-	b.balance(work) // Launches Balancer Loop
+// Dispatch finds Worker with the Lightest Load (or whichever Worker the
+// Strategy picks) and sends it the request. It returns the chosen Worker.
+// The least loaded Worker is read at the root without popping it; after
+// its pending rises it only has to sink, which Adjust (heap.Fix at index
+// 0) does in one pass.
+// The Balance Loop never waits for a Worker: if the chosen Worker's buffer
+// is full, nothing is dispatched and nil is returned (see schedule).
+func (b *Balancer) dispatch(req Request) *Worker {
+	root := b.root()
+	if w := b.preemptFor(req); w != nil { // Urgent: Took over a Worker
+		b.rerooted(root)
+		return w
+	}
+	w := b.peek()       // Get Item with least/equal low value (Stays in Heap)
+	w = b.avoid(w, req) // A Hedge goes Elsewhere
+	if !w.spare() {
+		return nil // Buffer Full: the Request waits for a free Slot
+	}
+	b.take()         // The Strategy moves on
+	b.assign(w, req) // Hand Request to the Worker
+	b.pool.Adjust(w) // Update Value in Heap! (Sift down from the Root)
+	b.rerooted(root)
+	return w
+}
+
+// Assign: Sends the request to the Worker and does the pending accounting.
+// The caller is responsible for restoring the Worker's heap position, and
+// for making sure the Worker has a free slot in its buffer (see spare).
+// Pending counts the estimated load (the sum of request costs), not the
+// number of requests, so big jobs weigh more in the heap ordering.
+func (b *Balancer) assign(w *Worker, req Request) {
+	b.seq++ // Number the Request
+	j := job{seq: b.seq, sent: time.Now(), cost: max(req.cost, 1), meta: req.meta, h: req.h, prio: req.prio, pre: req.pre}
+	req.seq = j.seq // Worker names it when Done
+	j.in = req.in
+	if j.in.IsZero() {
+		j.in = j.sent // Scheduled or Deferred: Submitted as it is Dispatched
+	}
+	if j.pre != nil {
+		j.req = req // Kept for a Re-queue
+	}
+	w.requests <- req   // Update Request Buffer
+	w.pending += j.cost // Advance Pending Count (+cost)
+	b.pending += j.cost // Advance Total Pending (+cost)
+	if w.pending == j.cost && b.onBusy != nil {
+		b.onBusy(w.id) // Was Idle
+	}
+	b.peak = max(b.peak, b.pending)
+	w.jobs = append(w.jobs, j) // Remember what was Sent
+	w.last = j.seq             // Most Recently Used
+	b.totals.Dispatched++
+	b.rate.dispatched(j.sent)
+	if req.h != nil {
+		req.h.worker.Store(int64(w.id)) // Tell the Submitter who got it
+	}
+	b.trace(Dispatched, w, j)
+}
+
+// Lookup: Returns the Worker with the given stable ID, or nil if the
+// ID is not in the Pool. Only safe from the Balance Loop goroutine.
+func (b *Balancer) lookup(id int) *Worker {
+	for _, w := range b.pool.Workers() { // Linear Scan (Pool is small)
+		if w.id == id {
+			return w
+		}
+	}
+	return nil
+}
+
+// HasWorker: Reports whether a Worker with the given stable ID is in the
+// Pool right now - false alike for IDs that never existed and for Workers
+// since removed - so affinity routing and reservations can fall back when
+// their target is gone. The answer comes from the Balance Loop, so it is
+// consistent with any concurrent change to the Pool. The Balance Loop must
+// be running.
+func (b *Balancer) HasWorker(id int) bool {
+	var ok bool
+	b.do(func() { ok = b.lookup(id) != nil })
+	return ok
+}
+
+// DispatchTo: TEST HOOK ONLY - Not for production callers!
+// Sends the request to the Worker with the given ID regardless of its load,
+// bypassing the "Lightest Load" selection, so tests can build a specific
+// imbalance deterministically. Reports false if no such Worker exists
+// or its buffer is full.
+func (b *Balancer) dispatchTo(id int, req Request) bool {
+	w := b.lookup(id)
+	if w == nil || !w.spare() {
+		return false
+	}
+	b.assign(w, req) // Hand Request to the Worker
+	b.pool.Adjust(w) // Re-position Item with its new pending value
+	return true
+}
+
+// Completed: When a request is completed the worker's Pending Value is
+// lowered and the worker is moved towards the root of the Pool in place.
+// The OnComplete hook (if any) sees the Worker before it is re-pushed.
+// A completion is matched to its job by Request Number, so a second
+// report for the same request (a bug, or a timeout racing the real
+// completion) finds no job and is ignored instead of corrupting pending.
+func (b *Balancer) completed(w *Worker, seq int64) {
+	k := slices.IndexFunc(w.jobs, func(j job) bool { return j.seq == seq })
+	if k < 0 {
+		if !w.stuck { // An Evicted Worker's late Report is expected
+			b.opts.logf("worker %d: request %d already completed, ignored", w.id, seq)
+		}
+		return
+	}
+	root := b.root()
+	j := w.jobs[k]
+	if k == 0 {
+		w.jobs = w.jobs[1:] // Worker runs its Requests in order: Oldest is done
+	} else {
+		w.jobs = slices.Delete(w.jobs, k, k+1)
+	}
+	w.pending -= j.cost // Update Pending Value (-cost)
+	b.pending -= j.cost // Update Total Pending (-cost)
+	if w.pending == 0 && b.onIdle != nil {
+		b.onIdle(w.id) // Nothing left
+	}
+	b.totals.Completed++
+	w.served++ // Lifetime Count (not Reset)
+	b.rate.completed(time.Now())
+	if !j.started.IsZero() {
+		w.queued.record(j.started.Sub(j.sent))  // Waiting in the Buffer
+		w.service.record(time.Since(j.started)) // Executing
+	}
+	b.trace(Completed, w, j)
+	latency := time.Since(j.sent) // Queue + Service Time
+	b.worst = max(b.worst, latency)
+	if b.onComplete != nil {
+		b.onComplete(CompletionInfo{WorkerID: w.id, Latency: latency, Pending: w.pending})
+	}
+	b.emit(w, j)
+	if !w.retired {
+		b.pool.Adjust(w) // Re-position Item with its new pending value
+	}
+	b.rerooted(root)
+	b.requeue(j) // Preempted: Run it again
 }
@@ -0,0 +1,157 @@
+package balance
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// Pool Sizes the benchmarks run over.
+var poolSizes = []int{10, 100, 1000}
+
+// Heap: A Heap of "n" Workers with assorted pending loads.
+func benchHeap(n int) *Pool {
+	o := &options{}
+	p := &Pool{}
+	for i := 0; i < n; i++ {
+		p.Add(&Worker{id: i, pending: i % 7, weight: 1, opts: o})
+	}
+	return p
+}
+
+// BenchmarkHeapUpdate: One dispatch and one completion on the Heap, the
+// old way (Pop+Push, Remove+Push) against heap.Fix (see Adjust).
+func BenchmarkHeapUpdate(b *testing.B) {
+	for _, n := range poolSizes {
+		b.Run(fmt.Sprintf("RemovePush/workers=%d", n), func(b *testing.B) {
+			p := benchHeap(n)
+			for i := 0; i < b.N; i++ {
+				w := heap.Pop(p).(*Worker) // Dispatch
+				w.pending++
+				heap.Push(p, w)
+				w = (*p)[i%n]
+				heap.Remove(p, w.i) // Completion
+				w.pending = max(w.pending-1, 0)
+				heap.Push(p, w)
+			}
+		})
+		b.Run(fmt.Sprintf("Fix/workers=%d", n), func(b *testing.B) {
+			p := benchHeap(n)
+			for i := 0; i < b.N; i++ {
+				w := p.Least() // Dispatch
+				w.pending++
+				p.Adjust(w)
+				w = (*p)[i%n] // Completion
+				w.pending = max(w.pending-1, 0)
+				p.Adjust(w)
+			}
+		})
+	}
+}
+
+// BenchmarkDispatch: Requests through the whole Balancer - intake,
+// dispatch, work and completion - for each Pool size.
+func BenchmarkDispatch(b *testing.B) {
+	for _, n := range poolSizes {
+		b.Run(fmt.Sprintf("workers=%d", n), func(b *testing.B) {
+			bal, err := NewBalancerConfig(Config{Workers: n})
+			if err != nil {
+				b.Fatal(err)
+			}
+			bal.Start()
+			defer bal.Close()
+			fn := func() int { return 0 }
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				bal.Fire(fn)
+			}
+			bal.WaitIdle(context.Background())
+		})
+	}
+}
+
+// HeapErr: Reports a Worker of "p" that sorts before its parent, or whose
+// index does not point at it.
+func heapErr(p Pool) error {
+	for i, w := range p {
+		if w.i != i {
+			return fmt.Errorf("worker %d at %d has index %d", w.id, i, w.i)
+		}
+		if i > 0 && p.Less(i, (i-1)/2) {
+			return fmt.Errorf("worker %d at %d sorts before its parent", w.id, i)
+		}
+	}
+	return nil
+}
+
+func TestHeapFixOnCompletion(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	p := benchHeap(50)
+	for i := 0; i < 10000; i++ {
+		w := (*p)[r.Intn(p.Len())]
+		if w.pending == 0 || r.Intn(3) == 0 {
+			w.pending += r.Intn(4) // Dispatched to meanwhile
+		} else {
+			w.pending-- // Completed
+		}
+		p.Adjust(w)
+		if err := heapErr(*p); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+}
+
+func TestHeapInvariantUnderLoad(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 8})
+	b.Start()
+	defer b.Close()
+	check := func() {
+		t.Helper()
+		var err error
+		b.do(func() { err = heapErr(*b.pool.(*Pool)) })
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 400; i++ {
+		d := time.Duration(r.Intn(200)) * time.Microsecond
+		b.Fire(func() int { time.Sleep(d); return 0 })
+		if i%20 == 0 {
+			check()
+		}
+	}
+	b.WaitIdle(context.Background())
+	check()
+}
+
+func TestHeapFixOnDispatch(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	p := benchHeap(50)
+	for _, w := range *p {
+		w.weight = 1 + r.Intn(4)
+	}
+	heap.Init(p)
+	for i := 0; i < 10000; i++ {
+		w := p.Least()
+		for _, o := range *p { // Exact: Nobody lighter per unit of Weight
+			if o.pending*w.weight < w.pending*o.weight {
+				t.Fatalf("step %d: picked worker %d (%d/%d), worker %d is lighter (%d/%d)",
+					i, w.id, w.pending, w.weight, o.id, o.pending, o.weight)
+			}
+		}
+		w.pending++ // Dispatch
+		p.Adjust(w)
+		if i%3 == 0 { // And a Completion now and then
+			c := (*p)[r.Intn(p.Len())]
+			c.pending = max(c.pending-1, 0)
+			p.Adjust(c)
+		}
+		if err := heapErr(*p); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+}
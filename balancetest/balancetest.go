@@ -0,0 +1,79 @@
+// Package balancetest: Test helpers for code built on the load balancer.
+package balancetest
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Balancer: The part of a Balancer the helpers need. *balance.Balancer
+// satisfies it.
+type Balancer interface {
+	Fire(fn func() int)                 // Submit without Waiting
+	Pending() []int                     // Pending Load per Worker (ID order)
+	WaitIdle(ctx context.Context) error // Block until nothing is Pending
+	QueueDepth() int                    // Requests each Worker buffers
+}
+
+// Per-worker depth of the uniform load, at most (a shallower queue depth
+// makes it shallower, so the load always fits the per-worker buffers).
+const depth = 8
+
+// Idle Timeout: How long the Pool gets to take and to finish the uniform
+// load.
+var idleTimeout = 10 * time.Second
+
+// AssertBalanced: Checks the package's core property - least-loaded
+// dispatch spreads uniform work evenly. It submits "depth" uniform
+// requests per Worker (no more than the Pool's queue depth), holds them
+// all pending at once, and fails the test if the difference between the
+// busiest and the idlest Worker, or the variance of the pending loads,
+// exceeds "tolerance" (0 demands a perfect spread). The requests are then
+// released and the Pool is waited on until idle. A Pool that does not
+// take the load, or does not finish it, in time fails the test instead
+// of hanging it. The Pool must be idle and otherwise unused during the
+// call.
+func AssertBalanced(t testing.TB, b Balancer, tolerance float64) {
+	t.Helper()
+	n := len(b.Pending()) * min(depth, b.QueueDepth())
+	gate := make(chan struct{}) // Holds every Request Pending
+	fired := make(chan struct{})
+	go func() {
+		defer close(fired)
+		for i := 0; i < n; i++ {
+			b.Fire(func() int {
+				<-gate
+				return 0
+			})
+		}
+	}()
+	select {
+	case <-fired:
+	case <-time.After(idleTimeout):
+		close(gate) // Let the Pool go
+		t.Fatalf("balancetest: pool did not take %d requests within %v", n, idleTimeout)
+	}
+	loads := b.Pending()
+	close(gate) // Release the Load
+	ctx, cancel := context.WithTimeout(context.Background(), idleTimeout)
+	defer cancel()
+	if err := b.WaitIdle(ctx); err != nil {
+		t.Fatalf("balancetest: pool did not go idle: %v", err)
+	}
+
+	lo, hi, sum, sumsq := loads[0], loads[0], 0, 0
+	for _, p := range loads {
+		lo, hi = min(lo, p), max(hi, p)
+		sum += p
+		sumsq += p * p
+	}
+	avg := float64(sum) / float64(len(loads))
+	variance := float64(sumsq)/float64(len(loads)) - avg*avg
+	if sum != n {
+		t.Errorf("balancetest: %d of %d requests pending", sum, n)
+	}
+	if float64(hi-lo) > tolerance || variance > tolerance {
+		t.Errorf("balancetest: unbalanced: loads %v (spread %d, variance %.2f, tolerance %.2f)", loads, hi-lo, variance, tolerance)
+	}
+}
@@ -0,0 +1,89 @@
+package balancetest
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/godfather667/balance"
+)
+
+// Recorder: A testing.TB that collects failures instead of reporting them.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	runtime.Goexit() // As testing.T: the Helper stops here
+}
+
+// Check: Runs AssertBalanced against "r" and returns its failures.
+func check(t *testing.T, b Balancer, tolerance float64) []string {
+	r := &recorder{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		AssertBalanced(r, b, tolerance)
+	}()
+	<-done
+	return r.failures
+}
+
+func TestAssertBalancedPasses(t *testing.T) {
+	for _, depth := range []int{1, 2, 100} { // Shallower and deeper than the Load
+		b, err := balance.NewBalancerConfig(balance.Config{Workers: 4, QueueDepth: depth})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Start()
+		if f := check(t, b, 0); len(f) > 0 {
+			t.Errorf("queue depth %d: %v", depth, f)
+		}
+		b.Close()
+	}
+}
+
+// Skewed: A Pool that piles every request onto its first Worker (and
+// runs none of them).
+type skewed struct {
+	loads []int
+}
+
+func (s *skewed) Fire(fn func() int) { s.loads[0]++ }
+
+func (s *skewed) Pending() []int { return append([]int(nil), s.loads...) }
+
+func (s *skewed) WaitIdle(ctx context.Context) error { return nil }
+
+func (s *skewed) QueueDepth() int { return 100 }
+
+func TestAssertBalancedFailsUnbalanced(t *testing.T) {
+	f := check(t, &skewed{loads: make([]int, 4)}, 1)
+	if len(f) != 1 {
+		t.Fatalf("failures %v, want one for the skewed loads", f)
+	}
+}
+
+// Stuck: A Pool whose intake never takes a request.
+type stuck struct{ skewed }
+
+func (s *stuck) Fire(fn func() int) { select {} }
+
+func TestAssertBalancedFailsStuck(t *testing.T) {
+	defer func(d time.Duration) { idleTimeout = d }(idleTimeout)
+	idleTimeout = 50 * time.Millisecond
+	f := check(t, &stuck{skewed{loads: make([]int, 4)}}, 0)
+	if len(f) != 1 {
+		t.Fatalf("failures %v, want one for the deadline", f)
+	}
+}
@@ -0,0 +1,99 @@
+package balance
+
+import "sync/atomic"
+
+// SubmitBatch: Runs every function in "fns" on the Pool and returns their
+// results in input order. All are submitted first, each with its own
+// buffered reply channel (see SubmitAsync), so the batch spreads across
+// the Workers and runs concurrently; the results are gathered afterwards,
+// whatever order they complete in. For a batch too big for the per-worker
+// buffers use MapLimited.
+func (b *Balancer) SubmitBatch(fns []func() int) []int {
+	cs := make([]<-chan int, len(fns))
+	for i, fn := range fns {
+		cs[i] = b.SubmitAsync(fn) // Submit All before Waiting
+	}
+	out := make([]int, len(fns))
+	for i, c := range cs {
+		out[i] = <-c // Gather in Input Order
+	}
+	return out
+}
+
+// MapLimited: Runs every function in "fns" on the Pool and returns their
+// results in input order, keeping at most "inflight" of them dispatched at
+// once. New functions are fed in as earlier ones complete, so a huge batch
+// never floods the per-worker buffers. Each function is still dispatched
+// to the least loaded Worker. A function that panics (and is recovered,
+// see PanicPolicy) yields 0, as with Submit.
+func (b *Balancer) MapLimited(fns []func() int, inflight int) []int {
+	if inflight < 1 {
+		inflight = 1 // At least one at a time
+	}
+	type reply struct{ i, v int }         // Result with its Input Index
+	replies := make(chan reply, inflight) // Room for every In-Flight reply
+	out := make([]int, len(fns))
+	for i, fn := range fns {
+		if i >= inflight { // Window full: Wait for a Completion
+			r := <-replies
+			out[r.i] = r.v
+		}
+		b.send(Request{fn: func() (v int) { // Push Request into "Work" Channel
+			defer func() { replies <- reply{i, v} }() // Even if it Panics: a Zero Result
+			return fn()
+		}, c: make(chan int, 1)}) // Reply is never read: buffer it
+	}
+	for n := min(inflight, len(fns)); n > 0; n-- { // Collect the Rest
+		r := <-replies
+		out[r.i] = r.v
+	}
+	return out
+}
+
+// MapFailFast: Runs every function in "fns" on the Pool, errgroup style.
+// If all succeed their results are returned in input order. On the first
+// error the remaining work is cancelled (queued functions never run,
+// running ones finish but are ignored; their pending counts are released
+// as usual) and that error is returned with NO results - any results
+// already collected are discarded, as a partial slice would be
+// indistinguishable from zeros. Functions not yet submitted when the
+// error is seen are not submitted at all. A function that panics (and is
+// recovered, see PanicPolicy) fails the batch with its PanicError.
+func (b *Balancer) MapFailFast(fns []func() (int, error)) ([]int, error) {
+	type reply struct {
+		i, v int
+		err  error
+	}
+	replies := make(chan reply, len(fns)) // Room for every reply: Abandoned work never blocks
+	var failed atomic.Bool                // An Error is in: Stop Submitting
+	hs := make([]*Handle, 0, len(fns))
+	for i, fn := range fns {
+		if failed.Load() {
+			break
+		}
+		hs = append(hs, b.SubmitHandle(func() (v int) {
+			var err error
+			defer func() { // Even if it Panics
+				if err != nil {
+					failed.Store(true)
+				}
+				replies <- reply{i, v, err}
+			}()
+			defer catch(func(e error) { err = e }) // Panicking: a PanicError
+			v, err = fn()
+			return v
+		}))
+	}
+	out := make([]int, len(fns))
+	for range hs { // One Reply per Submitted Function
+		r := <-replies
+		if r.err != nil { // Fail Fast: Cancel the Rest
+			for _, h := range hs {
+				h.Cancel()
+			}
+			return nil, r.err
+		}
+		out[r.i] = r.v
+	}
+	return out, nil
+}
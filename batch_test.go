@@ -0,0 +1,87 @@
+package balance
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// Boom: A work function that panics on input 1.
+func boom(i int) func() int {
+	return func() int {
+		if i == 1 {
+			panic("boom")
+		}
+		return i
+	}
+}
+
+func TestBatchesSurvivePanics(t *testing.T) {
+	b := New()
+	defer b.Close()
+	fns := []func() int{boom(0), boom(1), boom(2)}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if out := b.MapLimited(fns, 2); out[0] != 0 || out[1] != 0 || out[2] != 2 {
+			t.Errorf("MapLimited: %v", out)
+		}
+		_, err := b.MapFailFast([]func() (int, error){
+			func() (int, error) { return boom(1)(), nil },
+		})
+		if !errors.Is(err, ErrPanicked) {
+			t.Errorf("MapFailFast: %v", err)
+		}
+		for _, c := range b.SubmitBroadcast(2, boom(1)) {
+			if v := <-c; v != 0 {
+				t.Errorf("SubmitBroadcast: %d", v)
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a batch is still waiting for a panicked function")
+	}
+}
+
+func TestSubmitBatchOrder(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 4})
+	b.Start()
+	defer b.Close()
+	fns := make([]func() int, 50)
+	for i := range fns {
+		fns[i] = func() int { // Later Inputs finish First
+			time.Sleep(time.Duration(50-i) * 100 * time.Microsecond)
+			return i
+		}
+	}
+	out := b.SubmitBatch(fns)
+	for i, v := range out {
+		if v != i {
+			t.Fatalf("result %d is %d: batch %v out of input order", i, v, out)
+		}
+	}
+}
+
+func TestSubmitBatchBeyondBuffers(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 4, QueueDepth: 8})
+	b.Start()
+	fns := make([]func() int, 20000) // Far beyond Workers*QueueDepth
+	for i := range fns {
+		fns[i] = func() int { return i }
+	}
+	done := make(chan []int)
+	go func() { done <- b.SubmitBatch(fns) }()
+	select {
+	case out := <-done:
+		for i, v := range out {
+			if v != i {
+				t.Fatalf("result %d is %d: batch out of input order", i, v)
+			}
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("SubmitBatch never returned")
+	}
+	b.Close() // Not Deferred: a deadlocked Pool would never Close
+}
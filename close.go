@@ -0,0 +1,171 @@
+package balance
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrClosed: The Balancer has been closed and takes no more work.
+var ErrClosed = errors.New("balance: balancer closed")
+
+// Close: Shuts the Balancer down gracefully and frees its goroutines.
+// Intake closes at once, so nothing new is accepted, and a Pause ends;
+// the requests already taken in - including those waiting in the intake
+// buffer (see SetIntakeBuffer) and in the Workers' buffers - are still
+// run, and scheduled and deferred requests (SubmitAt, SubmitDeferred)
+// are dispatched at once instead of at their time (SubmitErr's retries
+// still in backoff fail with ErrClosed instead). Close waits until the
+// Pool is idle. Then every Worker goroutine and the Balance Loop exit,
+// and the Balancer is Stopped for good. Close returns nil; a second call
+// does nothing.
+//
+// After Close only State, Stats and Report are of use (Report returns
+// the summary taken at shutdown). Submissions that can report an error
+// return ErrClosed (TrySubmit and SubmitOrElse: false and the fallback,
+// Handles and Futures: ErrClosed); the others, which have no way to say
+// so, panic with ErrClosed. Control operations (Pause, Drain, Warmup,
+// ...) return at once. The Balance Loop must be running.
+func (b *Balancer) Close() error {
+	if !b.transit(Running, Stopped) && !b.transit(Draining, Stopped) && !b.transit(Paused, Stopped) {
+		return nil // Already Stopped (or Closing)
+	}
+	b.do(func() {
+		b.paused = false // Held Work runs before the end
+		b.seal()
+		b.flush()
+	})
+	for stopped := false; !stopped; {
+		b.idle(context.Background()) // Run the Work in hand
+		b.do(func() { stopped = b.stop() })
+	}
+	return nil
+}
+
+// Seal: Closes the intake and refuses new submissions (see hand). Only
+// called from the Balance Loop.
+func (b *Balancer) seal() {
+	if !b.sealed {
+		b.sealed = true
+		close(b.closing) // Refuse Late Submitters
+	}
+}
+
+// Flush: Dispatches the scheduled and deferred requests now, whatever
+// their time - except SubmitErr's retries in backoff, which fail with
+// ErrClosed instead. Only called from the Balance Loop.
+func (b *Balancer) flush() {
+	for _, d := range b.later.queue {
+		b.place(d.req)
+	}
+	b.later.queue = nil
+	due := b.retry.due // Due ones first
+	for _, d := range b.retry.queue {
+		due = append(due, d.req)
+	}
+	b.retry.queue = nil
+	for _, req := range due {
+		if req.h != nil { // A Retry (see attempt)
+			req.h.finish(0, ErrClosed)
+			continue
+		}
+		b.place(req)
+	}
+	b.retry.due = nil
+}
+
+// Stop: If no work is left, stops the Workers and the Balance Loop.
+// Requests handed in while the intake was being sealed (see hand) - into
+// the intake buffer or as scheduled or deferred work - are moved to the
+// Scheduler or dispatched instead, and Close waits for them too. Only
+// called from the Balance Loop.
+func (b *Balancer) stop() bool {
+	handing := b.sending.Load() // Before the Drain: a later Hand is Refused
+	for drained := false; !drained; {
+		select {
+		case req := <-b.work:
+			b.sched.Push(req) // Accepted: Run it
+		default:
+			drained = true
+		}
+	}
+	b.flush()
+	if handing > 0 || b.pending+b.held() > 0 {
+		return false // Leftovers: Wait for them too
+	}
+	for _, w := range b.workers {
+		close(w.requests) // Idle Worker: its goroutine exits
+	}
+	b.pulse.Stop()
+	for _, events := range b.events {
+		close(events) // Emitter exits once it has caught up
+	}
+	b.settle() // No Drain is left waiting
+	b.publish()
+	r := b.report()
+	b.final.Store(&r)
+	b.stopped = true // Loop exits after this Operation
+	close(b.exited)  // Control Operations return at once
+	return true
+}
+
+// Hand: Sends "v" to the Balance Loop on "c", unless the Balancer is
+// closing or "quit" (if not nil) is done first. Reports whether it was
+// sent. Close waits for every hand in progress, so nothing sent is lost.
+func hand[T any](b *Balancer, c chan<- T, v T, quit <-chan struct{}) bool {
+	b.sending.Add(1)
+	defer b.sending.Add(-1)
+	if b.closed() {
+		return false
+	}
+	select {
+	case c <- v:
+		return true
+	case <-b.closing:
+		return false
+	case <-quit:
+		return false
+	}
+}
+
+// Send: Hands a request to the intake, for the submissions that have no
+// error to report a refusal by: once the Balancer is closed they panic
+// with ErrClosed.
+func (b *Balancer) send(req Request) {
+	if !hand(b, b.work, req, nil) {
+		panic(ErrClosed)
+	}
+}
+
+// Closed: Whether Close has sealed the intake. Safe from any goroutine.
+func (b *Balancer) closed() bool {
+	select {
+	case <-b.closing:
+		return true
+	default:
+		return false
+	}
+}
+
+// Intake: The channel the Balance Loop takes new requests from; nil once
+// Close has sealed it (see stop for the intake buffer), while a blocking
+// Pool is full (see
+// SetMaxPending), while the rate limit holds dispatch back (see
+// SetRateLimit), while the Balancer is paused (see Pause), while the
+// next request's Worker has a full buffer (see schedule) and while a
+// Drain holds it.
+func (b *Balancer) intake() chan Request {
+	if b.paused || b.holds > 0 || b.sealed || b.stalled || (b.full() && !b.reject) || !b.limit.open() {
+		return nil
+	}
+	return b.work
+}
+
+// SetIntakeBuffer: Lets up to "n" submitted requests wait in the intake
+// while the Balance Loop is busy, instead of each submitter waiting for
+// the loop to take its request (0, the default, is unbuffered). The
+// non-blocking submissions (TrySubmit, SubmitOrElse) succeed while the
+// buffer has room. Must be called before the Balance Loop is started and
+// before anything is submitted.
+func (b *Balancer) SetIntakeBuffer(n int) {
+	b.work = make(chan Request, max(n, 0))
+}
@@ -0,0 +1,98 @@
+package balance
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Soon: Fails the test unless "fn" returns within a second.
+func soon(t *testing.T, what string, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("%s: still blocked after a second", what)
+	}
+}
+
+func TestCloseRunsAcceptedWork(t *testing.T) {
+	b := New()
+	var n atomic.Int64
+	for i := 0; i < 50; i++ {
+		b.Fire(func() int { time.Sleep(time.Millisecond); n.Add(1); return 0 })
+	}
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if n.Load() != 50 {
+		t.Fatalf("ran %d of 50 requests", n.Load())
+	}
+	if b.Close() != nil || b.State() != Stopped {
+		t.Fatal("second Close")
+	}
+}
+
+func TestCloseFlushesScheduledWork(t *testing.T) {
+	b := New()
+	at := b.SubmitAfter(time.Hour, func() int { return 1 })
+	later := b.SubmitDeferred(time.Hour, func() int { return 2 })
+	b.Close()
+	soon(t, "SubmitAfter", func() { <-at })
+	soon(t, "SubmitDeferred", func() { <-later })
+}
+
+func TestSubmitAfterClose(t *testing.T) {
+	b := New()
+	b.Close()
+	soon(t, "TrySubmit", func() {
+		if _, ok := b.TrySubmit(func() int { return 1 }); ok {
+			t.Error("TrySubmit accepted after Close")
+		}
+	})
+	soon(t, "SubmitContext", func() {
+		if _, err := b.SubmitContext(context.Background(), func() int { return 1 }); !errors.Is(err, ErrClosed) {
+			t.Errorf("SubmitContext: %v", err)
+		}
+	})
+	soon(t, "SubmitErr", func() {
+		if _, err := b.SubmitErr(func() (int, error) { return 1, nil }); !errors.Is(err, ErrClosed) {
+			t.Errorf("SubmitErr: %v", err)
+		}
+	})
+	soon(t, "SubmitHandle", func() {
+		if _, err := b.SubmitHandle(func() int { return 1 }).Wait(context.Background()); !errors.Is(err, ErrClosed) {
+			t.Errorf("SubmitHandle: %v", err)
+		}
+	})
+	soon(t, "Submit", func() {
+		defer func() {
+			if r := recover(); r != ErrClosed {
+				t.Errorf("Submit: recovered %v", r)
+			}
+		}()
+		b.Submit(func() int { return 1 })
+	})
+}
+
+func TestControlAfterClose(t *testing.T) {
+	b := New()
+	b.Close()
+	ctx := context.Background()
+	soon(t, "Pause", b.Pause)
+	soon(t, "Drain", func() {
+		if err := b.Drain(ctx); !errors.Is(err, ErrClosed) {
+			t.Errorf("Drain: %v", err)
+		}
+	})
+	if b.Healthy() {
+		t.Error("Healthy after Close")
+	}
+}
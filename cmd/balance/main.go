@@ -0,0 +1,56 @@
+// Copyright 2010 The Go Authors.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// Modifications, Annotations and explanations C.E. Thornton
+// The document expaining this program and components is available at:
+//    "hawthornepresscom@gmail.com" under Documents.
+//
+
+// Command balance: The demonstration of the load balancer. Requester
+// goroutines (see package loadgen) submit random amounts of simulated work
+// at random intervals, and the Balancer prints the pending count of each Worker, with their
+// average and variance, after every event.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"math/rand"
+	"os"
+
+	"github.com/godfather667/balance"
+	"github.com/godfather667/balance/loadgen"
+)
+
+// Number of Requester GO Routines and number of Worker GO Routines
+const nRequester = 100
+const nWorker = 10
+
+// The main function:
+// - Create Worker Pool and Start Worker Go Routines
+// - launch balancer Loop
+// - Create and start Request Goroutines
+func main() {
+	seed := flag.Int64("seed", 0, "Seed for the demo load (0 = time-seeded)")
+	flag.Parse()
+	b := balance.NewBalancer() // Create Worker Pool & Start Workers Goroutines
+	b.SetOutput(os.Stdout)     // Show the Pool after every Event
+	b.SetLogger(log.Default()) // Diagnostics to the Standard Logger
+	b.Start()                  // Launches Balancer Loop
+	work := make(chan balance.Request)
+	b.Feed(work) // The Requesters' Work Source
+	var master *rand.Rand
+	if *seed != 0 {
+		master = rand.New(rand.NewSource(*seed)) // Repeatable Run
+	}
+	for i := 0; i < nRequester; i++ {
+		var r *rand.Rand // Each Requester its own Source (Rand is not shared safely)
+		if master != nil {
+			r = rand.New(rand.NewSource(master.Int63()))
+		}
+		go loadgen.Requester(context.Background(), work, r, loadgen.RealClock{}, nWorker) // Create and start request Goroutines
+	}
+	select {} // Run until Interrupted
+}
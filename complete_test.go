@@ -0,0 +1,35 @@
+package balance
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestDoubleCompletionIgnored(t *testing.T) {
+	var out bytes.Buffer
+	b, _ := NewBalancerConfig(Config{Workers: 2})
+	b.SetLogger(log.New(&out, "", 0))
+	b.Start()
+	defer b.Close()
+	var w *Worker
+	var seq int64
+	b.do(func() {
+		b.dispatchTo(0, Request{fn: func() int { return 1 }, c: make(chan int, 1)})
+		w = b.lookup(0)
+		seq = w.jobs[0].seq
+	})
+	b.WaitIdle(context.Background())
+	b.do(func() { b.completed(w, seq) }) // The same Request reported again
+	if got := b.Pending(); got[0] != 0 || got[1] != 0 {
+		t.Fatalf("pending %v after a second completion, want [0 0]", got)
+	}
+	if n := b.Report().Processed; n != 1 {
+		t.Fatalf("%d completions counted, want 1", n)
+	}
+	if !strings.Contains(out.String(), "already completed, ignored") {
+		t.Fatalf("second completion not logged: %q", out.String())
+	}
+}
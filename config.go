@@ -0,0 +1,62 @@
+package balance
+
+import "fmt"
+
+// Config Structure: How a Balancer is sized (see NewBalancerConfig).
+type Config struct {
+	Name       string       // Labels Logs and Stats (as NewNamedBalancer)
+	Workers    int          // Number of Workers (at least 1)
+	QueueDepth int          // Requests each Worker buffers (0 = Default of 100)
+	Strategy   Strategy     // Worker Selection Policy (nil = LeastPending)
+	MaxPending int          // Pending Cap per Worker (0 = None, see SetMaxPending)
+	Overload   OverloadMode // What a full Pool does with a Request
+	Weights    []int        // Worker Weights by ID (Missing = 1, see SetWeight)
+	Intake     int          // Intake Buffer (0 = Unbuffered, see SetIntakeBuffer)
+	RateLimit  int          // Dispatches per Second (0 = Unthrottled, see SetRateLimit)
+	MinWorkers int          // Fewest Workers at Run Time (0 = 1, see SetPoolBounds)
+	MaxWorkers int          // Most Workers at Run Time (0 = No Limit, see SetPoolBounds)
+}
+
+// NewBalancerConfig: Creates a Balancer sized for the caller's workload
+// instead of the default 10 Workers buffering 100 requests each. The
+// completion channel, the Pool and every Worker's buffer are sized from
+// "cfg". A Pool without Workers, or a negative QueueDepth, is refused
+// with an error. The Balance Loop is not started (see Start). Once the
+// Worker a request is meant for has QueueDepth requests buffered, the
+// request waits in the Balancer and the intake is held until that Worker
+// has room, so a shallow queue pushes back on the submitters.
+func NewBalancerConfig(cfg Config) (*Balancer, error) {
+	if cfg.Workers < 1 {
+		return nil, fmt.Errorf("balance: config: %d workers, need at least 1", cfg.Workers)
+	}
+	if cfg.QueueDepth < 0 {
+		return nil, fmt.Errorf("balance: config: negative queue depth %d", cfg.QueueDepth)
+	}
+	depth := cfg.QueueDepth
+	if depth == 0 {
+		depth = nRequester // Default Buffer
+	}
+	b := newBalancer(cfg.Name, cfg.Workers, depth)
+	if cfg.Strategy != nil {
+		b.strat = cfg.Strategy
+	}
+	b.SetMaxPending(cfg.MaxPending, cfg.Overload)
+	b.SetIntakeBuffer(cfg.Intake)
+	b.SetRateLimit(cfg.RateLimit)
+	if !b.SetPoolBounds(cfg.MinWorkers, cfg.MaxWorkers) {
+		return nil, fmt.Errorf("balance: config: %d workers outside the bounds %d to %d", cfg.Workers, cfg.MinWorkers, cfg.MaxWorkers)
+	}
+	for id, weight := range cfg.Weights {
+		if !b.SetWeight(id, weight) {
+			return nil, fmt.Errorf("balance: config: weight %d for worker %d", weight, id)
+		}
+	}
+	b.publish() // Snapshot as Configured
+	return b, nil
+}
+
+// QueueDepth: Requests each Worker buffers (see Config). Safe from any
+// goroutine.
+func (b *Balancer) QueueDepth() int {
+	return b.depth
+}
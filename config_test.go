@@ -0,0 +1,46 @@
+package balance
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestShallowQueueFlood(t *testing.T) {
+	b, err := NewBalancerConfig(Config{Workers: 2, QueueDepth: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Start()
+	done := make(chan int)
+	go func() {
+		var replies []<-chan int
+		for i := 0; i < 2000; i++ { // Far more than the Buffers hold
+			replies = append(replies, b.SubmitAsync(func() int { return 1 }))
+		}
+		sum := 0
+		for _, c := range replies {
+			sum += <-c
+		}
+		done <- sum
+	}()
+	select {
+	case sum := <-done:
+		if sum != 2000 {
+			t.Fatalf("%d of 2000 requests answered", sum)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("deadlocked: a flood of a shallow queue never finished")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := b.WaitIdle(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for id, n := range b.Pending() {
+		if n != 0 {
+			t.Fatalf("worker %d: %d pending after the flood", id, n)
+		}
+	}
+	b.Close() // Not Deferred: a deadlocked Pool would never Close
+}
@@ -0,0 +1,96 @@
+package balance
+
+import "time"
+
+// Deferred Requests:
+// Work that is being retried (or deliberately put off) should not compete
+// with fresh requests at full priority, or a retry storm can starve new
+// work. Deferred requests wait in their own time-ordered queue; once due
+// they join a FIFO that the Balance Loop serves according to the
+// DeferPriority.
+type DeferPriority int
+
+const (
+	// DeferLow: Due deferred work is dispatched only when no new request
+	// is waiting (the default).
+	DeferLow DeferPriority = iota
+	// DeferEqual: Due deferred work takes turns with new requests.
+	DeferEqual
+	// DeferHigh: Due deferred work is dispatched ahead of new requests.
+	DeferHigh
+)
+
+// Deferral: The Balance Loop's deferred work state
+type deferral struct {
+	timetable           // Deferred Requests not yet Due
+	due       []Request // Due Requests awaiting Dispatch (Oldest first)
+	prio      DeferPriority
+}
+
+// Waiting: A channel that is ready while due requests are waiting, so the
+// Balance Loop's select offers them alongside its other events (or nil,
+// which never fires).
+func (d *deferral) waiting() <-chan time.Time {
+	if len(d.due) == 0 {
+		return nil
+	}
+	return always
+}
+
+// Always: A channel that is always ready to receive from.
+var always = func() <-chan time.Time {
+	c := make(chan time.Time)
+	close(c)
+	return c
+}()
+
+// Ripen: Moves deferred requests whose time has come to the due FIFO, or
+// straight to the backlog (ahead of new requests) at DeferHigh.
+func (b *Balancer) ripen() {
+	for _, req := range b.retry.expired() {
+		if b.retry.prio == DeferHigh {
+			b.place(req)
+			continue
+		}
+		b.retry.due = append(b.retry.due, req)
+	}
+}
+
+// ServeDeferred: Queues the oldest due deferred request for dispatch (see
+// place) - unless, at DeferLow, a new request is waiting, which then goes
+// first.
+func (b *Balancer) serveDeferred() {
+	if b.retry.prio == DeferLow {
+		select {
+		case req := <-b.intake(): // New Work goes First (if it may be taken)
+			req.in = time.Now()
+			if !b.turnedAway(req) {
+				b.sched.Push(req)
+				b.schedule() // Within the Cap and the Rate Limit
+			}
+			return
+		default:
+		}
+	}
+	req := b.retry.due[0]
+	b.retry.due = b.retry.due[1:]
+	b.place(req)
+}
+
+// SetDeferPriority: Sets how due deferred requests rank against new ones.
+// Must be called before the Balance Loop is started.
+func (b *Balancer) SetDeferPriority(p DeferPriority) {
+	b.retry.prio = p
+}
+
+// SubmitDeferred: Queues "fn" as deferred work, to be dispatched no
+// sooner than "d" from now and then at the DeferPriority - the way to
+// re-submit a failed request after a backoff. The result arrives on the
+// returned (buffered) channel.
+func (b *Balancer) SubmitDeferred(d time.Duration, fn func() int) <-chan int {
+	req := Request{fn: fn, c: make(chan int, 1)} // Buffered Reply Channel
+	if !hand(b, b.retry.in, delayed{time.Now().Add(d), req}, nil) {
+		panic(ErrClosed) // As Submit
+	}
+	return req.c
+}
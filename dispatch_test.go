@@ -0,0 +1,43 @@
+package balance
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+// Force: Puts "n" requests blocked on "gate" on Worker "id", whatever its
+// load (see dispatchTo).
+func force(t *testing.T, b *Balancer, id, n int, gate chan struct{}) {
+	t.Helper()
+	b.do(func() {
+		for i := 0; i < n; i++ {
+			if !b.dispatchTo(id, Request{fn: func() int { <-gate; return 0 }, c: make(chan int, 1)}) {
+				t.Errorf("dispatchTo(%d) refused", id)
+			}
+		}
+	})
+}
+
+func TestDispatchToBuildsImbalance(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 3})
+	b.Start()
+	defer b.Close()
+	gate := make(chan struct{})
+	defer close(gate)
+	force(t, b, 0, 3, gate)
+	force(t, b, 2, 1, gate)
+	if got := b.Pending(); !slices.Equal(got, []int{3, 0, 1}) {
+		t.Fatalf("pending %v, want [3 0 1]", got)
+	}
+	var ok bool
+	b.do(func() { ok = b.dispatchTo(99, Request{fn: func() int { return 0 }, c: make(chan int, 1)}) })
+	if ok {
+		t.Fatal("dispatchTo an unknown Worker succeeded")
+	}
+	h := b.SubmitHandle(func() int { return 1 })
+	h.Wait(context.Background())
+	if h.Worker() != 1 {
+		t.Fatalf("next request went to worker %d, want the idle worker 1", h.Worker())
+	}
+}
@@ -0,0 +1,12 @@
+// Package balance: A load balancer for goroutine Workers.
+//
+// Requests are dispatched to the least loaded Worker of a Pool; finished
+// requests are reported back, so the Balancer always knows each Worker's
+// pending load. A single goroutine - the Balance Loop - owns the Pool and
+// serializes every dispatch and completion.
+//
+//	b := balance.New()         // Create the Pool and start the Balance Loop
+//	result := b.Submit(myFn)   // Run myFn on the least loaded Worker
+//
+// The demonstration program lives in cmd/balance.
+package balance
@@ -0,0 +1,183 @@
+package balance
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Drain Progress Interval: How often Drain reports the remaining work.
+const drainTick = 100 * time.Millisecond
+
+// DrainPolicy: What Drain does with the remaining work if it is aborted.
+type DrainPolicy int
+
+const (
+	// DrainLeave: Stop waiting; the remaining work carries on as usual.
+	DrainLeave DrainPolicy = iota
+	// DrainCancel: Cancel the queued requests that can be cancelled, i.e.
+	// those submitted with a Handle (SubmitHandle, SubmitDone, streams,
+	// ...). Running requests finish, and plain submissions - whose callers
+	// are waiting for a value - still run.
+	DrainCancel
+)
+
+// DrainOption: Adjusts one Drain (see DrainProgress and DrainAbort).
+type DrainOption func(*drainPlan)
+
+// DrainPlan: How one Drain reports and ends.
+type drainPlan struct {
+	policy   DrainPolicy         // What an Aborted Drain does with the Rest
+	progress func(remaining int) // Progress Reports (nil = None)
+}
+
+// DrainProgress: Has Drain call "fn" every 100ms with the remaining load,
+// and a last time with 0 once the Pool is idle.
+func DrainProgress(fn func(remaining int)) DrainOption {
+	return func(p *drainPlan) { p.progress = fn }
+}
+
+// DrainAbort: Has Drain apply "policy" to the remaining work if its
+// context is done first (DrainLeave, the default, leaves it alone).
+func DrainAbort(policy DrainPolicy) DrainOption {
+	return func(p *drainPlan) { p.policy = policy }
+}
+
+// Drain: Waits until the work already taken in has finished, without
+// tearing anything down - e.g. before taking a consistent snapshot. The
+// intake is held meanwhile: the Balance Loop takes no new requests
+// (submitters queue up in the intake buffer or block), dispatches what it
+// holds and signals as soon as nothing is pending on the Workers, in the
+// backlog or in the Scheduler, so even steady traffic cannot keep a Drain
+// from settling. Then the intake opens again and the Balancer carries on
+// as before; unlike Close, the Workers keep running throughout. Scheduled
+// and deferred requests that are not yet due are not waited for.
+// If "ctx" is done first the abort policy is applied (see DrainAbort),
+// the intake opens all the same and the context's error is returned;
+// after Close ErrClosed is. The Balancer reports Draining meanwhile, so
+// it is not Healthy. The Balance Loop must be running.
+func (b *Balancer) Drain(ctx context.Context, opts ...DrainOption) error {
+	var plan drainPlan
+	for _, o := range opts {
+		o(&plan)
+	}
+	if b.transit(Running, Draining) {
+		defer b.transit(Draining, Running) // Intake reopens: Back to Running
+	}
+	idle := make(chan struct{})
+	if !b.do(func() {
+		b.holds++ // Hold the Intake
+		b.quiet = append(b.quiet, idle)
+	}) {
+		return ErrClosed
+	}
+	defer b.do(func() {
+		b.holds--
+		b.quiet = slices.DeleteFunc(b.quiet, func(c chan struct{}) bool { return c == idle })
+	})
+	err := b.await(ctx, idle, plan.progress)
+	if err != nil && plan.policy == DrainCancel { // Aborted
+		b.do(b.cancelQueued)
+	}
+	return err
+}
+
+// Await: Waits for "idle" to be closed (see settle) or "ctx" to be done,
+// reporting the remaining load to "progress" (if not nil) on every tick
+// and with 0 once idle.
+func (b *Balancer) await(ctx context.Context, idle chan struct{}, progress func(remaining int)) error {
+	t := time.NewTicker(drainTick)
+	defer t.Stop()
+	for {
+		if progress != nil {
+			n := 0
+			if !b.do(func() { n = b.pending + b.held() }) {
+				return ErrClosed
+			}
+			progress(n) // Report Progress
+		}
+		select {
+		case <-idle:
+			if progress != nil {
+				progress(0) // Idle
+			}
+			return nil
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Settle: Wakes the Drain waiters once nothing is pending or held (see
+// held). Only called from the Balance Loop.
+func (b *Balancer) settle() {
+	if len(b.quiet) == 0 || b.pending+b.held() > 0 {
+		return
+	}
+	for _, c := range b.quiet {
+		close(c) // Quiet: Wake the Waiter
+	}
+	b.quiet = nil
+}
+
+// WaitIdle: Blocks until the Pool is idle - nothing pending on the
+// Workers, held in the backlog or by the Scheduler, waiting in the intake
+// buffer or scheduled or deferred for later - or returns the context's
+// error (ErrClosed after Close). Unlike Drain it leaves the intake open,
+// so requests submitted meanwhile are waited for too, and the State alone.
+// The Balance Loop must be running.
+func (b *Balancer) WaitIdle(ctx context.Context) error {
+	return b.idle(ctx)
+}
+
+// Idle: Polls the remaining load until it reaches zero or "ctx" is done.
+func (b *Balancer) idle(ctx context.Context) error {
+	t := time.NewTicker(drainTick)
+	defer t.Stop()
+	for {
+		var n int
+		if !b.do(func() {
+			n = b.pending + b.held()
+			if !b.sealed { // Sealed: Close moves the rest itself (see stop)
+				n += len(b.work) + len(b.later.queue) + len(b.retry.queue) + len(b.retry.due)
+			}
+		}) {
+			return ErrClosed
+		}
+		if n == 0 {
+			return nil // Idle
+		}
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// CancelQueued: Cancels every cancellable request not yet running. Held
+// requests are dispatched (cancelled) or moved to the backlog, so the
+// Scheduler empties; a cancelled request costs its Worker no more than a
+// skip. Only called
+// from the Balance Loop.
+func (b *Balancer) cancelQueued() {
+	for _, w := range b.workers {
+		for _, j := range w.jobs {
+			if j.started.IsZero() && j.h != nil { // Still in the Buffer
+				j.h.Cancel()
+			}
+		}
+	}
+	for _, req := range b.backlog {
+		if req.h != nil {
+			req.h.Cancel()
+		}
+	}
+	for req, ok := b.sched.Next(); ok; req, ok = b.sched.Next() {
+		if req.h != nil {
+			req.h.Cancel()
+		}
+		b.place(req)
+	}
+}
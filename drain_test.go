@@ -0,0 +1,158 @@
+package balance
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDrainProgress(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 2})
+	b.Start()
+	defer b.Close()
+	for i := 0; i < 20; i++ {
+		b.Fire(func() int { time.Sleep(20 * time.Millisecond); return 0 })
+	}
+	var seen []int
+	if err := b.Drain(context.Background(), DrainProgress(func(n int) { seen = append(seen, n) })); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) < 3 || seen[len(seen)-1] != 0 {
+		t.Fatalf("progress %v, want several reports ending in 0", seen)
+	}
+	for i := 1; i < len(seen); i++ {
+		if seen[i] > seen[i-1] {
+			t.Fatalf("progress %v went up", seen)
+		}
+	}
+	if b.State() != Running {
+		t.Fatalf("state %v after Drain, want Running", b.State())
+	}
+}
+
+func TestDrainAborted(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 1})
+	b.Start()
+	defer b.Close()
+	gate := make(chan struct{})
+	b.Fire(func() int { <-gate; return 0 })
+	queued := b.SubmitHandle(func() int { return 1 })
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := b.Drain(ctx, DrainAbort(DrainCancel)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Drain: %v, want the context's error", err)
+	}
+	close(gate)
+	if _, err := queued.Wait(context.Background()); !errors.Is(err, ErrCanceled) {
+		t.Fatalf("queued request: %v, want ErrCanceled", err)
+	}
+}
+
+func TestDrainHoldsIntake(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 2})
+	b.Start()
+	defer b.Close()
+	gate := make(chan struct{})
+	b.Fire(func() int { <-gate; return 0 })
+	quiet := make(chan error, 1)
+	go func() { quiet <- b.Drain(context.Background()) }()
+	for b.State() != Draining {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond) // Let the Hold reach the Loop
+	var ran atomic.Bool
+	held := make(chan struct{})
+	go func() { // Blocks (or Buffers) while the Intake is held
+		defer close(held)
+		b.Fire(func() int { ran.Store(true); return 0 })
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if ran.Load() {
+		t.Fatal("a request submitted during Drain ran while the intake was held")
+	}
+	select {
+	case err := <-quiet:
+		t.Fatalf("Drain returned %v with work still pending", err)
+	default:
+	}
+	close(gate)
+	select {
+	case err := <-quiet:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Drain still waiting after the pending work finished")
+	}
+	<-held
+	if err := b.WaitIdle(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !ran.Load() {
+		t.Fatal("the held request never ran after Drain")
+	}
+	if b.State() != Running {
+		t.Fatalf("state %v after Drain, want Running", b.State())
+	}
+}
+
+func TestDrainTimeout(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 2})
+	b.Start()
+	defer b.Close()
+	gate := make(chan struct{})
+	defer close(gate)
+	b.Fire(func() int { <-gate; return 0 })
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := b.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Drain: %v, want the context's error", err)
+	}
+	if b.State() != Running {
+		t.Fatalf("state %v after an aborted Drain, want Running", b.State())
+	}
+	got := make(chan int, 1)
+	go func() { got <- b.Submit(func() int { return 7 }) }()
+	select {
+	case v := <-got:
+		if v != 7 {
+			t.Fatalf("Submit after Drain: %d, want 7", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("intake still held after an aborted Drain")
+	}
+}
+
+func TestDrainSettlesUnderSteadyTraffic(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 2})
+	b.Start()
+	defer b.Close()
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() { // Submits for as long as the Test runs
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				b.Fire(func() int { time.Sleep(time.Millisecond); return 0 })
+			}
+		}
+	}()
+	time.Sleep(20 * time.Millisecond) // Traffic flowing
+
+	healthy := false // Seen Healthy during the Drain
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := b.Drain(ctx, DrainProgress(func(int) { healthy = healthy || b.Healthy() }))
+	close(stop)
+	<-done // Before Close: a blocked Fire would panic
+	if err != nil {
+		t.Fatalf("Drain under steady traffic: %v", err)
+	}
+	if healthy {
+		t.Error("reported Healthy while draining")
+	}
+}
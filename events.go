@@ -0,0 +1,51 @@
+package balance
+
+import "time"
+
+// CompletionEvent Structure: The full timeline of one finished request,
+// for latency histograms and tracing.
+type CompletionEvent struct {
+	ID         int64         `json:"id"`         // Request Number (as TraceEvent.Seq)
+	WorkerID   int           `json:"worker"`     // Stable ID of the Worker that ran it
+	Submitted  time.Time     `json:"submitted"`  // Taken in by the Balance Loop
+	Dispatched time.Time     `json:"dispatched"` // Sent to the Worker
+	Started    time.Time     `json:"started"`    // Begun by the Worker (zero = Not Reported)
+	Completed  time.Time     `json:"completed"`  // Reported Done
+	Latency    time.Duration `json:"latency_ns"` // Submitted to Completed
+}
+
+// OnCompletionEvent: Adds a sink for a CompletionEvent per finished
+// request; sinks added before stay installed, and each gets every event.
+// Unlike OnComplete a sink does NOT run on the Balance Loop: the loop
+// drops each event into the sink's own buffer of "buffer" events (at
+// least 1) and a goroutine of its own feeds them to "fn" in completion
+// order, so a slow sink never holds up dispatch or the other sinks. If a
+// sink falls so far behind that its buffer is full the event is dropped
+// for it and counted (Stats Dropped). Close stops the goroutines after
+// the last event. Must be called before the Balance Loop is started.
+func (b *Balancer) OnCompletionEvent(fn func(CompletionEvent), buffer int) {
+	events := make(chan CompletionEvent, max(buffer, 1))
+	b.events = append(b.events, events)
+	go func() {
+		for e := range events { // Emitter: Off the Loop
+			fn(e)
+		}
+	}()
+}
+
+// Emit: Queues the event for a finished job, never blocking. Only called
+// from the Balance Loop.
+func (b *Balancer) emit(w *Worker, j job) {
+	if len(b.events) == 0 {
+		return
+	}
+	now := time.Now()
+	e := CompletionEvent{ID: j.seq, WorkerID: w.id, Submitted: j.in, Dispatched: j.sent, Started: j.started, Completed: now, Latency: now.Sub(j.in)}
+	for _, events := range b.events {
+		select {
+		case events <- e:
+		default: // Sink behind: Drop it
+			b.lost.Add(1)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package balance
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+)
+
+// Expvar Registry Lock: expvar.Publish panics on a duplicate name, so the
+// check and the registration must not be split by another PublishExpvar.
+var expvarMu sync.Mutex
+
+// PublishExpvar: Publishes the Balancer's Stats Snapshot as the expvar
+// variable "name", visible at /debug/vars with no metrics stack. Every
+// read takes the latest Snapshot, so it is always current and never races
+// the Balance Loop. Give each Balancer its own name; a name that is
+// already taken (by this Balancer or anything else) is refused with an
+// error rather than the panic expvar itself would raise.
+func (b *Balancer) PublishExpvar(name string) error {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("balance: expvar %q already published", name)
+	}
+	expvar.Publish(name, expvar.Func(func() any { return b.Stats() }))
+	return nil
+}
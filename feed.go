@@ -0,0 +1,46 @@
+package balance
+
+// NewRequest: A Request to run "fn", for an application's own work source
+// (see Feed). Its reply channel (see Reply) has room for the result, so
+// the Worker never waits for the source to read it.
+func NewRequest(fn func() int) Request {
+	return Request{fn: fn, c: make(chan int, 1)} // Buffered Reply Channel
+}
+
+// Reply: The channel the result of the Request arrives on, once.
+func (r Request) Reply() <-chan int {
+	return r.c
+}
+
+// Feed: Plugs an application's work source into the intake - an HTTP
+// handler, a message-queue consumer, a load generator (as in the demo).
+// Every Request read from "in" (made with NewRequest) is submitted as if
+// by Submit, in the order read, and its result arrives on its Reply
+// channel. Feeding blocks while the Balance Loop does not take requests
+// (busy, full, throttled or paused), which pushes back on the source.
+// Feed returns at once; any number of sources may feed one Balancer. A
+// zero Request is skipped. The feed ends when "in" is closed, or when the
+// Balancer is: Close stops it, and the Request it had in hand (if any) is
+// never run and gets no reply; nothing more is read from "in".
+func (b *Balancer) Feed(in <-chan Request) {
+	go func() {
+		for {
+			var req Request
+			select {
+			case r, ok := <-in:
+				if !ok {
+					return // Source closed
+				}
+				req = r
+			case <-b.closing:
+				return // Balancer closed
+			}
+			if req.fn == nil {
+				continue // Zero Request: Nothing to Run
+			}
+			if !hand(b, b.work, req, nil) { // Push Request into "Work" Channel
+				return // Balancer closed
+			}
+		}
+	}()
+}
@@ -0,0 +1,42 @@
+package balance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFeed(t *testing.T) {
+	b := New()
+	defer b.Close()
+	in := make(chan Request)
+	b.Feed(in)
+	var reqs []Request
+	for i := 0; i < 30; i++ {
+		req := NewRequest(func() int { return i })
+		reqs = append(reqs, req)
+		in <- req
+		if i == 5 {
+			in <- Request{} // Skipped
+		}
+	}
+	close(in)
+	for i, req := range reqs {
+		if v := <-req.Reply(); v != i {
+			t.Fatalf("request %d replied %d", i, v)
+		}
+	}
+}
+
+func TestFeedEndsOnClose(t *testing.T) {
+	b := New()
+	in := make(chan Request)
+	b.Feed(in)
+	in <- NewRequest(func() int { return 1 })
+	b.Close() // Must not panic the feed
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case in <- NewRequest(func() int { return 2 }):
+		t.Fatal("feed still reading after Close")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
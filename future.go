@@ -0,0 +1,72 @@
+package balance
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrPanicked: Reported for a request whose work function panicked (and
+// was recovered, see PanicPolicy).
+var ErrPanicked = errors.New("balance: work function panicked")
+
+// Future: The pending result of one submission, to block on, poll, or
+// select on alongside other channels. Any number of goroutines may use it.
+type Future[T any] struct {
+	done chan struct{} // Closed when the Result is in
+	val  T             // Result of the Work Function
+	err  error         // Why there is no Result, or nil
+}
+
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// Resolve: Records the Result and wakes all Waiters. Called exactly once.
+func (f *Future[T]) resolve(v T, err error) {
+	f.val, f.err = v, err
+	close(f.done)
+}
+
+// Get: Blocks until the result is in or "ctx" is done. A context expiry
+// only stops this wait; the request itself carries on.
+func (f *Future[T]) Get(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.val, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done: Closed once the result is in, for use in a select.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// IsReady: Reports without blocking whether the result is in.
+func (f *Future[T]) IsReady() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// SubmitFuture: Submits "fn" and returns its Future without waiting.
+// If "fn" panics and the panic is recovered the Future reports a
+// PanicError (matching ErrPanicked).
+func (b *Balancer) SubmitFuture(fn func() int) *Future[int] {
+	f := newFuture[int]()
+	req := Request{fn: func() int {
+		defer catch(func(err error) { f.resolve(0, err) }) // Panicking: Release the Waiters
+		v := fn()
+		f.resolve(v, nil)
+		return v
+	}, c: make(chan int, 1)} // Reply is never read: buffer it
+	if !hand(b, b.work, req, nil) {
+		f.resolve(0, ErrClosed) // Never Submitted
+	}
+	return f
+}
@@ -0,0 +1,195 @@
+package balance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrCanceled: Reported by a Handle whose request was cancelled.
+var ErrCanceled = errors.New("balance: request canceled")
+
+// Handle: One submitted request, for waiting on or cancelling it.
+// Any number of goroutines may Wait; they all see the same result.
+type Handle struct {
+	once     sync.Once     // Result is set exactly once
+	done     chan struct{} // Closed when the Result is in
+	val      int           // Result of the Work Function
+	err      error         // ErrCanceled, or nil
+	canceled atomic.Bool   // Set by Cancel (Checked before the Work runs)
+	worker   atomic.Int64  // Stable ID of the chosen Worker (-1 = Not yet)
+	stop     func()        // Cancels the Work's Context (nil = None)
+}
+
+// SubmitHandle: Submits "fn" and returns its Handle without waiting.
+func (b *Balancer) SubmitHandle(fn func() int) *Handle {
+	return b.submitHandle(&Handle{done: make(chan struct{})}, fn)
+}
+
+// SubmitCancelable: Like SubmitHandle, but "fn" receives a context that is
+// cancelled when the Handle is, so long-running work can stop early (see
+// CheckCancel).
+func (b *Balancer) SubmitCancelable(fn func(ctx context.Context) int) *Handle {
+	return b.cancelable(fn, nil)
+}
+
+// Cancelable: SubmitCancelable, optionally as the hedge of "twin".
+func (b *Balancer) cancelable(fn func(ctx context.Context) int, twin *Handle) *Handle {
+	ctx, stop := context.WithCancel(context.Background())
+	h := &Handle{done: make(chan struct{}), stop: stop}
+	return b.submit(Request{h: h, twin: twin}, func() int {
+		defer stop() // Release the Context
+		return fn(ctx)
+	})
+}
+
+func (b *Balancer) submitHandle(h *Handle, fn func() int) *Handle {
+	return b.submit(Request{h: h}, fn)
+}
+
+// Submit: Sends a Handle's request, wrapping its Work Function.
+func (b *Balancer) submit(req Request, fn func() int) *Handle {
+	req.h.worker.Store(-1)                            // Not Dispatched yet
+	req.fn, req.c = req.h.wrap(fn), make(chan int, 1) // Reply is never read: buffer it
+	if !hand(b, b.work, req, nil) {
+		req.h.finish(0, ErrClosed) // Never Submitted
+	}
+	return req.h
+}
+
+// Worker: Stable ID of the Worker the request was dispatched to, or -1
+// if it has not been dispatched yet. Useful for checking what the
+// Strategy is doing and for tying slow requests to a Worker.
+func (h *Handle) Worker() int {
+	return int(h.worker.Load())
+}
+
+// Wrap: The Work Function the Worker actually runs. A request cancelled
+// while still queued is skipped; either way the Worker reports done, so
+// the pending accounting is untouched by cancellation. If the work
+// panics the Handle reports a PanicError.
+func (h *Handle) wrap(fn func() int) func() int {
+	return func() int {
+		if h.canceled.Load() {
+			return 0 // Cancelled before it Started
+		}
+		defer catch(func(err error) { h.finish(0, err) }) // Panicking: Release the Waiters
+		v := fn()
+		h.finish(v, nil)
+		return v
+	}
+}
+
+// Finish: Records the Result and wakes all Waiters (first call wins).
+func (h *Handle) finish(v int, err error) {
+	h.once.Do(func() {
+		h.val, h.err = v, err
+		close(h.done)
+	})
+}
+
+// Wait: Blocks until the request completes or "ctx" is done. A context
+// expiry only stops this wait; the request itself carries on.
+func (h *Handle) Wait(ctx context.Context) (int, error) {
+	select {
+	case <-h.done:
+		return h.val, h.err
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// Cancel: Abandons the request. Waiters are released at once with
+// ErrCanceled; a request still queued is never run, but one already
+// running finishes on its Worker (its result is dropped) - unless it was
+// submitted with SubmitCancelable and notices its context. Cancelling a
+// completed request has no effect.
+func (h *Handle) Cancel() {
+	h.canceled.Store(true)
+	h.finish(0, ErrCanceled)
+	if h.stop != nil {
+		h.stop() // Tell Cooperative Work to Stop
+	}
+}
+
+// CheckCancel: The cooperative yield point for long-running work.
+// Go cannot interrupt a running function, so a work function that loops
+// or crunches for a long time should call CheckCancel(ctx) every so often
+// and return promptly (with any value - it is discarded) once it reports
+// true. Work that never checks simply runs to completion.
+func CheckCancel(ctx context.Context) bool {
+	return ctx.Err() != nil
+}
+
+// SubmitDone: Submits "fn" and waits for its result, or until "done" is
+// closed - for code that signals cancellation with a channel rather than
+// a context. On "done" the request is cancelled as by Handle.Cancel (a
+// queued request never runs; either way its pending count is released
+// normally) and ErrCanceled is returned.
+func (b *Balancer) SubmitDone(done <-chan struct{}, fn func() int) (int, error) {
+	h := b.SubmitHandle(fn)
+	select {
+	case <-h.done:
+		return h.val, h.err
+	case <-done:
+		h.Cancel()
+		return 0, ErrCanceled
+	}
+}
+
+// SubmitContext: Submits "fn" and waits for its result, or until "ctx" is
+// done, in which case ctx.Err() is returned. The context bounds the whole
+// wait: if the Balancer does not take the request before "ctx" is done it
+// is never submitted; if it is queued it is cancelled and never runs; if
+// it is already running it finishes on its Worker and the result is
+// dropped. Either way the reply channel is buffered, so the Worker never
+// blocks on the abandoned request, and its pending count is released
+// normally.
+func (b *Balancer) SubmitContext(ctx context.Context, fn func() int) (int, error) {
+	h := &Handle{done: make(chan struct{})}
+	h.worker.Store(-1) // Not Dispatched yet
+	req := Request{fn: h.wrap(fn), c: make(chan int, 1), gone: ctx.Done(), h: h}
+	if !hand(b, b.work, req, ctx.Done()) { // Never Submitted
+		if b.closed() {
+			return 0, ErrClosed
+		}
+		return 0, ctx.Err()
+	}
+	select {
+	case <-h.done:
+		return h.val, h.err
+	case <-ctx.Done():
+		h.Cancel()
+		return 0, ctx.Err()
+	}
+}
+
+// SubmitGroup: Submits requests that share a lifetime, such as the
+// sub-tasks of one HTTP request. Each function receives a context derived
+// from "ctx"; cancelling "ctx" cancels every Handle of the group at once
+// (see Cancel) - queued members never run, finished ones keep their
+// results, and each member's pending count is released exactly once
+// either way. Handles are returned in input order.
+func (b *Balancer) SubmitGroup(ctx context.Context, fns []func(ctx context.Context) int) []*Handle {
+	hs := make([]*Handle, len(fns))
+	for i, fn := range fns {
+		mctx, stop := context.WithCancel(ctx) // Member Context
+		hs[i] = b.submitHandle(&Handle{done: make(chan struct{}), stop: stop}, func() int {
+			defer stop() // Release the Context
+			return fn(mctx)
+		})
+	}
+	unhook := context.AfterFunc(ctx, func() { // Group Cancelled
+		for _, h := range hs {
+			h.Cancel()
+		}
+	})
+	go func() { // Whole Group Done: Nothing left to Cancel
+		for _, h := range hs {
+			<-h.done
+		}
+		unhook()
+	}()
+	return hs
+}
@@ -0,0 +1,41 @@
+package balance
+
+import (
+	"net/http"
+	"time"
+)
+
+// Heartbeat: The Balance Loop records a beat on every iteration and at
+// least this often when idle. The loop counts as wedged once it misses
+// several beats in a row.
+const heartbeat = time.Second
+const missedBeats = 5
+
+// Heartbeat: Records that the Balance Loop just went round.
+func (b *Balancer) heartbeat() {
+	b.beat.Store(time.Now().UnixNano())
+}
+
+// Healthy: Reports whether the Balancer can serve work: there is at least
+// one Worker and the Balance Loop has beaten recently (so it is running
+// and not stuck). False before the Balance Loop is started, while it is
+// Draining - so a load balancer in front stops routing traffic to it -
+// and once Close has begun.
+func (b *Balancer) Healthy() bool {
+	last := time.Unix(0, b.beat.Load())
+	state := b.State()
+	return state != Stopped && state != Draining && b.Size() > 0 && time.Since(last) < missedBeats*heartbeat
+}
+
+// HealthHandler: An http.Handler for readiness probes - 200 while
+// Healthy, 503 otherwise, so an orchestrator can take a wedged instance
+// out of rotation.
+func (b *Balancer) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if !b.Healthy() {
+			http.Error(rw, "unhealthy", http.StatusServiceUnavailable)
+			return
+		}
+		rw.Write([]byte("ok\n"))
+	})
+}
@@ -0,0 +1,42 @@
+package balance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Probe: The status code of the Balancer's HealthHandler.
+func probe(b *Balancer) int {
+	rec := httptest.NewRecorder()
+	b.HealthHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+	return rec.Code
+}
+
+func TestUnhealthyWhileDraining(t *testing.T) {
+	b := New()
+	defer b.Close()
+	b.Pending() // One Loop Iteration: a Heartbeat
+	if !b.Healthy() || probe(b) != http.StatusOK {
+		t.Fatal("a running Balancer is not healthy")
+	}
+	gate := make(chan struct{})
+	b.Fire(func() int { <-gate; return 0 })
+	drained := make(chan error, 1)
+	go func() { drained <- b.Drain(context.Background()) }()
+	for b.State() != Draining {
+		time.Sleep(time.Millisecond)
+	}
+	if b.Healthy() || probe(b) != http.StatusServiceUnavailable {
+		t.Error("a draining Balancer reports healthy")
+	}
+	close(gate)
+	if err := <-drained; err != nil {
+		t.Fatal(err)
+	}
+	if !b.Healthy() || probe(b) != http.StatusOK {
+		t.Fatal("not healthy again after Drain")
+	}
+}
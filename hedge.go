@@ -0,0 +1,74 @@
+package balance
+
+import (
+	"context"
+	"time"
+)
+
+// Fallback Hedge Delay: Used while nothing has been measured yet.
+const hedgeFallback = 10 * time.Millisecond
+
+// SubmitHedged: Submission with hedging, to cut tail latency. If "fn" has
+// not completed within "delay", a duplicate is dispatched to a different
+// Worker; whichever copy finishes first supplies the result and the other
+// is cancelled (see Cancel - a queued copy never runs, a running one is
+// told through its context). Both copies count as pending while they are
+// outstanding, and the loser's count is released normally when it stops.
+// A "delay" of 0 or less is tuned from the Pool: twice the mean latency
+// (queue plus service time) measured so far. The work function MUST be
+// idempotent - it may run twice.
+func (b *Balancer) SubmitHedged(delay time.Duration, fn func(ctx context.Context) int) int {
+	if delay <= 0 {
+		delay = b.hedgeDelay()
+	}
+	first := b.SubmitCancelable(fn)
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-first.done: // In Time: No Hedge
+		return first.val
+	case <-t.C:
+	}
+	second := b.cancelable(fn, first) // Hedge on another Worker
+	select {
+	case <-first.done:
+		second.Cancel()
+		return first.val
+	case <-second.done:
+		first.Cancel()
+		return second.val
+	}
+}
+
+// HedgeDelay: Twice the mean latency over the Workers' completed jobs.
+func (b *Balancer) hedgeDelay() time.Duration {
+	var total time.Duration
+	var n int64
+	for _, w := range b.Stats().Workers {
+		total += time.Duration(w.Queue.Count) * (w.Queue.Mean + w.Service.Mean)
+		n += w.Queue.Count
+	}
+	if n == 0 {
+		return hedgeFallback // Nothing Measured yet
+	}
+	return 2 * total / time.Duration(n)
+}
+
+// Avoid: For a hedge, a different Worker than the one its twin went to
+// (the least loaded of the others), if there is one. Only called from
+// the Balance Loop.
+func (b *Balancer) avoid(w *Worker, req Request) *Worker {
+	if req.twin == nil || int64(w.id) != req.twin.worker.Load() {
+		return w // No Twin, or not on this Worker
+	}
+	var alt *Worker
+	for _, o := range b.workers {
+		if o != w && (alt == nil || o.pending < alt.pending) {
+			alt = o
+		}
+	}
+	if alt == nil {
+		return w // Only one Worker
+	}
+	return alt
+}
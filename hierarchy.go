@@ -0,0 +1,63 @@
+package balance
+
+// SubPool: What a parent level needs of a child pool - its load, a way
+// to run work on it, and its Snapshot. A *Balancer is one, and so are a
+// ShardedBalancer and a Hierarchy, so the levels nest.
+type SubPool interface {
+	Load() int             // Total Pending Load
+	Stats() *Stats         // Snapshot (for Roll-up)
+	run(fn func() int) int // Run "fn" on the Pool and wait for it
+}
+
+// Hierarchy: A pool of pools, e.g. one Balancer per region under a global
+// front door. Each request goes to the child with the least total load,
+// which then picks its own least loaded Worker - the Balancer's design
+// applied one level up. Child loads are read from their Snapshots, so
+// under a sudden burst several requests may pick the same child before
+// its load shows the first of them.
+type Hierarchy struct {
+	children []SubPool
+}
+
+// NewHierarchy: Builds a Hierarchy over running child pools (their
+// Balance Loops must already be started).
+func NewHierarchy(children ...SubPool) *Hierarchy {
+	return &Hierarchy{children: children}
+}
+
+// Submit: Runs "fn" on the least loaded child and waits for it.
+func (h *Hierarchy) Submit(fn func() int) int {
+	return h.least().run(fn)
+}
+
+// Least: The child with the least total load (the first on a tie).
+func (h *Hierarchy) least() SubPool {
+	best, load := h.children[0], h.children[0].Load()
+	for _, c := range h.children[1:] {
+		if l := c.Load(); l < load {
+			best, load = c, l
+		}
+	}
+	return best
+}
+
+// Load: Total pending load across the children.
+func (h *Hierarchy) Load() int {
+	n := 0
+	for _, c := range h.children {
+		n += c.Load()
+	}
+	return n
+}
+
+func (h *Hierarchy) run(fn func() int) int { return h.Submit(fn) }
+
+// Stats: One Snapshot rolled up over every level below. Worker IDs are
+// offset child by child so they stay unique (see rollUp).
+func (h *Hierarchy) Stats() *Stats {
+	parts := make([]*Stats, len(h.children))
+	for i, c := range h.children {
+		parts[i] = c.Stats()
+	}
+	return rollUp("hierarchy", parts) // Children may run different Strategies
+}
@@ -0,0 +1,63 @@
+package balance
+
+import "time"
+
+// CompletionInfo Structure: What the Balancer knows about a finished job
+type CompletionInfo struct {
+	WorkerID int           // Stable ID of the Worker that ran it
+	Latency  time.Duration // Dispatch to Completion (Queue + Service Time)
+	Pending  int           // Worker's Pending Count after this Completion
+}
+
+// OnComplete: Installs a hook called for EVERY completion.
+// The hook runs inside the Balance Loop, serialized with dispatch, so it can
+// update its own bookkeeping without locks - but it is on the hot path and
+// every other request waits while it runs: keep it fast and never block.
+// Must be called before the Balance Loop is started.
+func (b *Balancer) OnComplete(fn func(CompletionInfo)) {
+	b.onComplete = fn
+}
+
+// OnRootChange: Installs a hook called whenever a dispatch or completion
+// changes WHICH Worker is the least loaded (the root of the Queue), with
+// the stable IDs before and after. It shows routing dynamics - such as
+// two Workers taking turns - far more clearly than pending counts. Off
+// by default; like OnComplete it runs inside the Balance Loop, so keep it
+// fast. Must be called before the Balance Loop is started.
+func (b *Balancer) OnRootChange(fn func(oldID, newID int)) {
+	b.onRoot = fn
+}
+
+// Root: Stable ID of the least loaded Worker, for OnRootChange (-1 if
+// there is no hook, so the lookup costs nothing).
+func (b *Balancer) root() int {
+	if b.onRoot == nil {
+		return -1
+	}
+	return b.pool.Least().id
+}
+
+// Rerooted: Fires OnRootChange if the root is no longer "old".
+func (b *Balancer) rerooted(old int) {
+	if b.onRoot == nil {
+		return
+	}
+	if id := b.pool.Least().id; id != old {
+		b.onRoot(old, id)
+	}
+}
+
+// OnWorkerBusy / OnWorkerIdle: Install edge-triggered hooks fired when a
+// Worker's pending load rises from zero (busy) and falls back to zero
+// (idle), e.g. to attach a pooled connection to a Worker only while it
+// has work. Each fires exactly once per transition: busy on the dispatch
+// that wakes the Worker, idle on the completion that empties it. They run
+// inside the Balance Loop, so keep them fast. Must be called before the
+// Balance Loop is started.
+func (b *Balancer) OnWorkerBusy(fn func(id int)) {
+	b.onBusy = fn
+}
+
+func (b *Balancer) OnWorkerIdle(fn func(id int)) {
+	b.onIdle = fn
+}
@@ -0,0 +1,82 @@
+package balance
+
+import "time"
+
+// RequestInfo Structure: One request a Worker is executing right now
+type RequestInfo struct {
+	WorkerID int               // Stable ID of the Worker running it
+	Seq      int64             // Request Number (Order of Dispatch)
+	Age      time.Duration     // Time since the Worker began it
+	Meta     map[string]string // Labels attached at submission
+}
+
+// Begun: A Worker has started its oldest queued Job. Only the Balance
+// Loop calls this, so the running/queued split is always consistent with
+// the pending counts.
+func (b *Balancer) begun(w *Worker) {
+	for i := range w.jobs { // First Job not yet started
+		if w.jobs[i].started.IsZero() {
+			w.jobs[i].started = time.Now()
+			return
+		}
+	}
+}
+
+// CatchUp: Handles every start report already sent. A Worker reports a
+// Job's start before its completion, but the Balance Loop's select may
+// pick the completion first; catching up before each completion keeps the
+// two in order.
+func (b *Balancer) catchUp() {
+	for {
+		select {
+		case w := <-b.begin:
+			b.begun(w)
+		default:
+			return
+		}
+	}
+}
+
+// Do: Runs "fn" inside the Balance Loop and waits for it, giving "fn" a
+// consistent view of the Pool. Reports false, without running "fn", once
+// Close has stopped the loop. The Balance Loop must be running.
+func (b *Balancer) do(fn func()) bool {
+	done := make(chan struct{})
+	select {
+	case b.ctl <- func() {
+		fn()
+		close(done)
+	}:
+	case <-b.exited:
+		return false // Closed: No Loop to run it
+	}
+	<-done
+	return true
+}
+
+// InFlight: Lists the requests that are executing (not merely queued),
+// oldest first per Worker - what a saturated Pool is actually working on.
+// The list is taken inside the Balance Loop, so it is consistent.
+func (b *Balancer) InFlight() []RequestInfo {
+	var list []RequestInfo
+	b.do(func() {
+		now := time.Now()
+		for _, w := range b.workers {
+			for _, j := range w.jobs {
+				if j.started.IsZero() {
+					break // The rest are Queued
+				}
+				list = append(list, RequestInfo{WorkerID: w.id, Seq: j.seq, Age: now.Sub(j.started), Meta: j.meta})
+			}
+		}
+	})
+	return list
+}
+
+// SubmitMeta: Submits "fn" with labels that InFlight reports while it
+// runs, and waits for its result.
+func (b *Balancer) SubmitMeta(meta map[string]string, fn func() int) int {
+	req := Request{fn: fn, c: b.replyChan(), meta: meta}
+	b.send(req)    // Push Request into "Work" Channel
+	return <-req.c // Wait for "Done" Reply
+}
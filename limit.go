@@ -0,0 +1,31 @@
+package balance
+
+// SetExecutionLimit: Caps how many work functions run at once across the
+// whole Pool, however many Workers there are. A Worker that takes a
+// request while the cap is reached waits for a free slot before it starts
+// (its request counts as pending, and queued, meanwhile). 0, the default,
+// means no cap beyond one execution per Worker. Must be called before the
+// Balance Loop is started.
+func (b *Balancer) SetExecutionLimit(n int) {
+	b.opts.slots = nil
+	if n > 0 {
+		b.opts.slots = make(chan struct{}, n)
+	}
+}
+
+// Acquire: Waits for an execution slot (if capped) and counts the
+// execution as active.
+func (w *Worker) acquire() {
+	if w.opts.slots != nil {
+		w.opts.slots <- struct{}{} // Take a Slot (Waits at the Cap)
+	}
+	w.opts.active.Add(1)
+}
+
+// Release: Ends an execution started with acquire.
+func (w *Worker) release() {
+	w.opts.active.Add(-1)
+	if w.opts.slots != nil {
+		<-w.opts.slots // Give the Slot back
+	}
+}
@@ -0,0 +1,62 @@
+// Package loadgen: The simulated load of the demo, as a work source for
+// the load balancer. Requesters submit random amounts of simulated work
+// at random intervals through the Balancer's Feed. All their waiting is
+// done on a Clock, so a fake one (for tests) runs the load without any
+// wall-clock delays.
+package loadgen
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/godfather667/balance"
+)
+
+// Clock: Where the load waits. The real one sleeps; a fake one (for
+// tests) can advance time instantly, and with a seeded source the arrival
+// and service pattern then repeats exactly, with no wall-clock delays.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// RealClock: The Clock of the running demo - the wall clock.
+type RealClock struct{}
+
+func (RealClock) Now() time.Time        { return time.Now() }
+func (RealClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// Requester: Infinite Loop - Wait ... Send Request ... Wait for done.
+// Creates the Work Requests and sends them to "work", the Balancer's Feed
+// - one example of a work source - until "ctx" is done. Waits last up to
+// 2*scale seconds and the work up to "scale" seconds (the demo passes its
+// Worker count, so the load suits the Pool). All its randomness (waits
+// and work) comes from "r", so a seeded source repeats the same arrival
+// and service pattern; nil means time-seeded. All its waiting is done on
+// "clk".
+func Requester(ctx context.Context, work chan<- balance.Request, r *rand.Rand, clk Clock, scale int64) {
+	if r == nil {
+		r = rand.New(rand.NewSource(clk.Now().UnixNano())) // Unrepeatable Default
+	}
+	fn := func() int { return Op(r, clk, scale) } // Work Function (Runs while we Wait)
+	for ctx.Err() == nil {                        // Loop until Stopped
+		clk.Sleep(time.Duration(r.Int63n(scale * 2e9))) // Random Wait
+		req := balance.NewRequest(fn)
+		select {
+		case work <- req: // Send Request to the Balancer
+		case <-ctx.Done():
+			return
+		}
+		<-req.Reply() // Wait for "Done" Reply
+	}
+}
+
+// Op: Simulation of some work: just sleep for a while (up to "scale"
+// seconds) and report how long, in units of "scale" nanoseconds. All the
+// Work Requests of a Requester instantiate it.
+func Op(r *rand.Rand, clk Clock, scale int64) int { // Actual Simulated Work Function
+	n := r.Int63n(1e9)
+	clk.Sleep(time.Duration(scale * n)) // Sleep random amount
+	return int(n)                       // Return time slept(value not used)
+}
@@ -0,0 +1,210 @@
+package loadgen
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godfather667/balance"
+)
+
+// FakeClock: A Clock that records every Sleep and advances at once. It
+// calls "stop" once it has slept "limit" times.
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	slept []time.Duration
+	limit int
+	stop  func()
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.slept = append(c.slept, d)
+	if len(c.slept) == c.limit {
+		c.stop()
+	}
+}
+
+func TestRequesterTiming(t *testing.T) {
+	const seed, scale, requests = 7, 10, 3
+	b := balance.New()
+	defer b.Close()
+	work := make(chan balance.Request)
+	b.Feed(work)
+	ctx, stop := context.WithCancel(context.Background())
+	clk := &fakeClock{now: time.Unix(0, 0), limit: 2 * requests, stop: stop}
+	start := time.Now()
+	Requester(ctx, work, rand.New(rand.NewSource(seed)), clk, scale)
+	if time.Since(start) > time.Second {
+		t.Fatalf("fake-clock run took %v of real time", time.Since(start))
+	}
+	r := rand.New(rand.NewSource(seed)) // Replay the Draws
+	var want []time.Duration
+	var total time.Duration
+	for i := 0; i < requests; i++ {
+		wait := time.Duration(r.Int63n(scale * 2e9))
+		run := time.Duration(scale * r.Int63n(1e9))
+		want = append(want, wait, run)
+		total += wait + run
+	}
+	if len(clk.slept) != len(want) {
+		t.Fatalf("slept %d times, want %d", len(clk.slept), len(want))
+	}
+	for i, d := range want {
+		if clk.slept[i] != d {
+			t.Errorf("sleep %d: %v, want %v", i, clk.slept[i], d)
+		}
+		if d >= 2*scale*time.Second {
+			t.Errorf("sleep %d: %v, over the bound of %v", i, d, 2*scale*time.Second)
+		}
+	}
+	if got := clk.Now().Sub(time.Unix(0, 0)); got != total {
+		t.Errorf("clock advanced %v, want %v", got, total)
+	}
+}
+
+// ManualClock: A Clock that only moves when the test advances it; a
+// Sleep blocks until then.
+type manualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	wakers  []waker
+	stopped bool // Sleeps return at once
+}
+
+// Waker: A Sleep in progress.
+type waker struct {
+	at time.Time
+	c  chan struct{}
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	if c.stopped {
+		c.mu.Unlock()
+		return
+	}
+	w := waker{c.now.Add(d), make(chan struct{})}
+	c.wakers = append(c.wakers, w)
+	c.mu.Unlock()
+	<-w.c
+}
+
+// Advance: Moves the clock on by "d" and wakes every Sleep that is due.
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	left := c.wakers[:0]
+	for _, w := range c.wakers {
+		if w.at.After(c.now) {
+			left = append(left, w)
+			continue
+		}
+		close(w.c)
+	}
+	c.wakers = left
+}
+
+// Stop: Wakes every Sleep, now and to come.
+func (c *manualClock) stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+	for _, w := range c.wakers {
+		close(w.c)
+	}
+	c.wakers = nil
+}
+
+// Sleeping: How many Sleeps are in progress.
+func (c *manualClock) sleeping() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.wakers)
+}
+
+// Settle: Polls "cond" until it holds, failing the test after a second.
+func settle(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for end := time.Now().Add(time.Second); !cond(); time.Sleep(time.Millisecond) {
+		if time.Now().After(end) {
+			t.Fatalf("%s: not reached within a second", what)
+		}
+	}
+}
+
+func TestDispatchUnderClock(t *testing.T) {
+	// Request i sleeps work[i] on the clock; the clock then advances by
+	// 2s, which finishes each Worker's first request if it is that short.
+	work := []time.Duration{time.Second, 5 * time.Second, 2 * time.Second, time.Second, time.Second, 3 * time.Second}
+	for _, tc := range []struct {
+		strategy balance.Strategy
+		weights  []int
+		targets  []int // Worker of each Request
+		queued   []int // Pending per Worker, all Requests dispatched
+		final    []int // Pending per Worker after the Advance
+	}{
+		{balance.LeastPending, nil, []int{0, 1, 2, 0, 1, 2}, []int{2, 2, 2}, []int{1, 2, 1}},
+		{balance.NewRoundRobin(), nil, []int{0, 1, 2, 0, 1, 2}, []int{2, 2, 2}, []int{1, 2, 1}},
+		{balance.NewWeightedRoundRobin(), []int{1, 2, 3}, []int{2, 1, 0, 2, 1, 2}, []int{1, 2, 3}, []int{0, 2, 2}},
+	} {
+		t.Run(tc.strategy.Name(), func(t *testing.T) {
+			b, err := balance.NewBalancerConfig(balance.Config{Workers: 3, Strategy: tc.strategy, Weights: tc.weights})
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.Start()
+			defer b.Close()
+			clk := &manualClock{now: time.Unix(0, 0)}
+			defer clk.stop() // Let the rest finish before Close
+			var hs []*balance.Handle
+			for _, d := range work {
+				hs = append(hs, b.SubmitHandle(func() int { clk.Sleep(d); return 0 }))
+			}
+			if p := b.Pending(); !slices.Equal(p, tc.queued) { // Also: every Request Dispatched
+				t.Fatalf("pending %v with every request queued, want %v", p, tc.queued)
+			}
+			for i, h := range hs {
+				if h.Worker() != tc.targets[i] {
+					t.Errorf("request %d went to worker %d, want %d", i, h.Worker(), tc.targets[i])
+				}
+			}
+			settle(t, "first requests running", func() bool { return clk.sleeping() == 3 })
+			clk.Advance(2 * time.Second)
+			left, busy := 0, 0
+			for _, n := range tc.final {
+				left += n
+				busy += min(n, 1)
+			}
+			settle(t, "short requests done", func() bool {
+				sum := 0
+				for _, n := range b.Pending() {
+					sum += n
+				}
+				return sum == left && clk.sleeping() == busy // Next Requests Running
+			})
+			if p := b.Pending(); !slices.Equal(p, tc.final) {
+				t.Fatalf("pending %v after the clock advanced, want %v", p, tc.final)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package balance
+
+// Logger: Where the Balancer's diagnostics go (see SetLogger) - recovered
+// panics, evicted Workers, dropped replies and the like. A *log.Logger
+// is one.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// SetLogger: Sends the Balancer's diagnostics to "l", each line prefixed
+// with the Balancer's label. Nil (the default) keeps the Balancer silent;
+// log.Default() sends them to the standard logger.
+// Must be called before the Balance Loop is started.
+func (b *Balancer) SetLogger(l Logger) {
+	b.opts.logger = l
+}
+
+// Label: How log lines are prefixed - "balance", or "balance[name]" for
+// a named Balancer.
+func (o *options) label() string {
+	if o.name == "" {
+		return "balance"
+	}
+	return "balance[" + o.name + "]"
+}
+
+// Logf: Logs a line labelled with the Balancer's name, if there is a
+// Logger to log to.
+func (o *options) logf(format string, args ...interface{}) {
+	if o.logger == nil {
+		return // Silent by Default
+	}
+	o.logger.Printf(o.label()+": "+format, args...)
+}
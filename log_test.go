@@ -0,0 +1,32 @@
+package balance
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestLoggerSilentByDefault(t *testing.T) {
+	var std bytes.Buffer
+	defer log.SetOutput(log.Writer()) // Restore the Standard Logger
+	log.SetOutput(&std)
+	b := New()
+	b.Submit(func() int { panic("boom") }) // Recovered: Logged, if at all
+	b.Close()
+	if std.Len() != 0 {
+		t.Fatalf("default Balancer logged %q", std.String())
+	}
+}
+
+func TestSetLogger(t *testing.T) {
+	var out bytes.Buffer
+	b := NewNamedBalancer("pool")
+	b.SetLogger(log.New(&out, "", 0))
+	b.Start()
+	b.Submit(func() int { panic("boom") })
+	b.Close()
+	if line := out.String(); !strings.HasPrefix(line, "balance[pool]: worker ") || !strings.Contains(line, "recovered panic: boom") {
+		t.Fatalf("logged %q", line)
+	}
+}
@@ -0,0 +1,81 @@
+package balance
+
+import "sync"
+
+// Result Memory:
+// When results are big, what limits a Balancer is the memory of results
+// computed but not yet taken by their submitters, not the request count.
+// SubmitSized estimates each result's size (with a caller-supplied
+// estimator) and counts it from the moment it is computed until the
+// submitter takes it. With a limit set (SetResultMemoryLimit), new sized
+// submissions wait while the total is at or above the limit. Requests
+// submitted any other way are not counted and never wait.
+
+// MemGate Structure: The running total and the limit, with a condition
+// for submitters waiting on the limit.
+type memGate struct {
+	mu    sync.Mutex
+	cond  sync.Cond
+	used  int64 // Estimated Bytes of Results not yet Taken
+	limit int64 // Wait while used >= limit (0 = No Limit)
+}
+
+// Add: Adjusts the total, waking the waiters when it falls.
+func (g *memGate) add(n int64) {
+	g.mu.Lock()
+	g.used += n
+	g.mu.Unlock()
+	if n < 0 {
+		g.cond.Broadcast()
+	}
+}
+
+// Wait: Blocks while the total is at or above the limit.
+func (g *memGate) wait() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for g.limit > 0 && g.used >= g.limit {
+		g.cond.Wait()
+	}
+}
+
+// Load: Current total.
+func (g *memGate) load() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.used
+}
+
+// SetResultMemoryLimit: Sets the estimated result memory (in the units
+// the estimators return, normally bytes) at which new SubmitSized calls
+// wait for results to be taken. 0, the default, means no limit. Safe to
+// call at any time.
+func (b *Balancer) SetResultMemoryLimit(n int64) {
+	b.mem.mu.Lock()
+	b.mem.limit = max(n, 0)
+	b.mem.mu.Unlock()
+	b.mem.cond.Broadcast() // A raised Limit may let Waiters in
+}
+
+// SubmitSized: Submits "fn" and returns a channel delivering its result,
+// accounting the result's estimated memory ("size" of the result) until it
+// is read from the channel. While the estimated total is at or above the
+// limit, SubmitSized waits before submitting. A nil "size" counts nothing.
+// The channel must be read, or the result is held (and counted) forever.
+func (b *Balancer) SubmitSized(size func(v int) int, fn func() int) <-chan int {
+	b.mem.wait() // Backpressure: Result Memory at the Limit
+	c := make(chan int, 1)
+	b.send(Request{fn: fn, c: c}) // Push Request into "Work" Channel
+	out := make(chan int)
+	go func() { // Hold the Result until it is Taken
+		v := <-c
+		n := int64(0)
+		if size != nil {
+			n = int64(size(v))
+		}
+		b.mem.add(n)
+		out <- v
+		b.mem.add(-n)
+	}()
+	return out
+}
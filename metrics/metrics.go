@@ -0,0 +1,86 @@
+// Package metrics: A Prometheus exporter for the load balancer.
+// It lives in a package of its own so only programs that import it pull
+// in the Prometheus client; the balance package itself stays free of it.
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/godfather667/balance"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector: Exports one Balancer to Prometheus.
+// Per-Worker pending loads and the dispatch and completion totals are
+// read from the Stats Snapshot at scrape time, so a scrape never races the
+// Balance Loop, and completions are counted even when the event stream
+// drops events. Only the latency histogram is fed by the completion-event
+// stream.
+type Collector struct {
+	b         *balance.Balancer
+	pending   *prometheus.Desc
+	dispatch  *prometheus.Desc
+	completed *prometheus.Desc
+	latency   prometheus.Histogram // Submitted to Completed (Seconds)
+}
+
+// Event Buffer: Completion Events the Balance Loop may queue for the
+// exporter before it drops them (see OnCompletionEvent).
+const eventBuffer = 1024
+
+// Register: Creates a Collector for "b" and registers it with "reg".
+// It adds a completion-event sink (OnCompletionEvent) alongside any the
+// caller installed, so it must be called before the Balance Loop is
+// started. Labels: Metric names carry the prefix "balance_", and the
+// Balancer's name (if any) is a constant "balancer" label, so several
+// Balancers can share a registry.
+func Register(b *balance.Balancer, reg prometheus.Registerer) (*Collector, error) {
+	var labels prometheus.Labels
+	if name := b.Stats().Name; name != "" {
+		labels = prometheus.Labels{"balancer": name}
+	}
+	c := &Collector{
+		b: b,
+		pending: prometheus.NewDesc("balance_worker_pending",
+			"Weighted pending load of each Worker.", []string{"worker"}, labels),
+		dispatch: prometheus.NewDesc("balance_dispatched_total",
+			"Requests sent to Workers.", nil, labels),
+		completed: prometheus.NewDesc("balance_completed_total",
+			"Requests finished.", nil, labels),
+		latency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name: "balance_request_latency_seconds", Help: "Time from intake to completion.",
+			ConstLabels: labels, Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10)}),
+	}
+	if err := reg.Register(c); err != nil {
+		return nil, err
+	}
+	b.OnCompletionEvent(c.observe, eventBuffer)
+	return c, nil
+}
+
+// Observe: Records the latency of one completion; runs on the emitter
+// goroutine, off the Balance Loop.
+func (c *Collector) observe(e balance.CompletionEvent) {
+	c.latency.Observe(e.Latency.Seconds())
+}
+
+// Describe: Implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.pending
+	ch <- c.dispatch
+	ch <- c.completed
+	c.latency.Describe(ch)
+}
+
+// Collect: Implements prometheus.Collector from the latest Snapshot.
+// ResetStats restarts the dispatch and completion totals, which
+// Prometheus handles as counter resets.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	s := c.b.Stats()
+	for _, w := range s.Workers {
+		ch <- prometheus.MustNewConstMetric(c.pending, prometheus.GaugeValue, float64(w.Pending), strconv.Itoa(w.ID))
+	}
+	ch <- prometheus.MustNewConstMetric(c.dispatch, prometheus.CounterValue, float64(s.Totals.Dispatched))
+	ch <- prometheus.MustNewConstMetric(c.completed, prometheus.CounterValue, float64(s.Totals.Completed))
+	c.latency.Collect(ch)
+}
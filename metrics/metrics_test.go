@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/godfather667/balance"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCompletedFromSnapshot(t *testing.T) {
+	b := balance.NewNamedBalancer("pool")
+	reg := prometheus.NewRegistry()
+	if _, err := Register(b, reg); err != nil {
+		t.Fatal(err)
+	}
+	b.Start()
+	defer b.Close()
+	for i := 0; i < 5; i++ {
+		b.Submit(func() int { return i })
+	}
+	if err := b.WaitIdle(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]float64{}
+	for _, f := range families {
+		if m := f.GetMetric()[0]; m.GetCounter() != nil {
+			got[f.GetName()] = m.GetCounter().GetValue()
+		}
+	}
+	if got["balance_completed_total"] != 5 || got["balance_dispatched_total"] != 5 {
+		t.Fatalf("totals %v, want 5 dispatched and completed", got)
+	}
+}
+
+func TestRegisterKeepsEventSink(t *testing.T) {
+	b := balance.NewBalancer()
+	seen := make(chan balance.CompletionEvent, 5)
+	b.OnCompletionEvent(func(e balance.CompletionEvent) { seen <- e }, 5)
+	reg := prometheus.NewRegistry()
+	if _, err := Register(b, reg); err != nil {
+		t.Fatal(err)
+	}
+	b.Start()
+	defer b.Close()
+	for i := 0; i < 5; i++ {
+		b.Submit(func() int { return i })
+	}
+	for i := 0; i < 5; i++ {
+		select {
+		case <-seen:
+		case <-time.After(time.Second):
+			t.Fatalf("caller's sink got %d of 5 events after Register", i)
+		}
+	}
+	deadline := time.Now().Add(time.Second)
+	for n := latencies(t, reg); n != 5; n = latencies(t, reg) { // Exporter still catching up
+		if time.Now().After(deadline) {
+			t.Fatalf("latency histogram has %d samples, want 5", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Latencies: Sample count of the latency histogram in "reg".
+func latencies(t *testing.T, reg *prometheus.Registry) uint64 {
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		if f.GetName() == "balance_request_latency_seconds" {
+			return f.GetMetric()[0].GetHistogram().GetSampleCount()
+		}
+	}
+	return 0
+}
@@ -0,0 +1,56 @@
+package balance
+
+import "time"
+
+// Variance Monitor:
+// The Balancer computes the variance of the pending counts but a single
+// high value means little - bursts spread out again within a few events.
+// A variance that STAYS high usually means a stuck or slow worker, so the
+// monitor only alerts once the variance has been above the threshold for
+// the whole configured duration.
+type varianceMonitor struct {
+	threshold float64                // Variance Alert Level
+	duration  time.Duration          // How long it must stay above the level
+	alert     func(variance float64) // Called once per high excursion
+	since     time.Time              // When variance went high (zero = low)
+	fired     bool                   // Alert already sent for this excursion
+}
+
+// MonitorVariance: Installs the Imbalance Alarm.
+// "alert" is called once when the variance has remained above "threshold"
+// for at least "d"; it re-arms when the variance drops back below. A nil
+// alert logs the event instead. Must be called before the Balance Loop is
+// started. The alert runs on the Balance Loop, so it must be quick.
+func (b *Balancer) MonitorVariance(threshold float64, d time.Duration, alert func(variance float64)) {
+	if alert == nil {
+		alert = func(variance float64) {
+			b.opts.logf("variance %.2f above %.2f for %v", variance, threshold, d)
+		}
+	}
+	b.monitor = &varianceMonitor{threshold: threshold, duration: d, alert: alert}
+}
+
+// Check: Runs on each Balance Loop event - and on every heartbeat tick,
+// so a stalled or idle Pool is caught without any traffic - and fires the
+// alert if the variance has been high long enough. The monitor lives in
+// the loop, so it sees the same consistent pool state as print().
+func (b *Balancer) check() {
+	m := b.monitor
+	if m == nil { // Monitor Off
+		return
+	}
+	_, variance := b.spread()
+	if variance <= m.threshold { // Back to normal: Re-arm
+		m.since = time.Time{}
+		m.fired = false
+		return
+	}
+	now := time.Now()
+	if m.since.IsZero() { // Excursion Starts
+		m.since = now
+	}
+	if !m.fired && now.Sub(m.since) >= m.duration {
+		m.fired = true // Once per Excursion
+		m.alert(variance)
+	}
+}
@@ -0,0 +1,30 @@
+package balance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonitorFiresWithoutTraffic(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 2})
+	alerts := make(chan float64, 1)
+	b.MonitorVariance(1, 50*time.Millisecond, func(v float64) { alerts <- v })
+	b.Start()
+	gate := make(chan struct{})
+	defer b.Close()
+	defer close(gate)
+	b.do(func() { // Worker 0 stalls under a Pile of Work
+		for i := 0; i < 6; i++ {
+			b.dispatchTo(0, Request{fn: func() int { <-gate; return 0 }, c: make(chan int, 1)})
+		}
+	})
+	// No Request or Completion follows: only the heartbeat can notice.
+	select {
+	case v := <-alerts:
+		if v <= 1 {
+			t.Fatalf("alert at variance %.2f, below the threshold", v)
+		}
+	case <-time.After(3 * heartbeat):
+		t.Fatal("monitor never fired for a stalled Pool without traffic")
+	}
+}
@@ -0,0 +1,61 @@
+package balance
+
+// OverloadMode: What happens to a request when every Worker is full (see
+// SetMaxPending).
+type OverloadMode int
+
+const (
+	// OverloadBlock: Hold the request until a Worker has room. The Balance
+	// Loop stops taking new requests meanwhile, so submitters block on the
+	// intake - backpressure all the way back to the producers.
+	OverloadBlock OverloadMode = iota
+	// OverloadReject: Refuse the request at once with ErrSaturated. Only
+	// submissions that can report an error are refused - those with a
+	// Handle (SubmitHandle, SubmitContext, SubmitDone, SubmitPriority, ...)
+	// and SubmitErr; the others have no way to hear of a refusal and are
+	// held in the Scheduler until a Worker has room, as with OverloadBlock
+	// (the intake stays open meanwhile, so Fire and SubmitAsync do not
+	// block). A request held before the Pool filled up is not refused.
+	OverloadReject
+)
+
+// SetMaxPending: Caps the pending load of the least loaded Worker: once
+// it reaches "n" the Pool counts as full and requests are held or refused
+// according to "mode" until a completion makes room (0, the default, sets
+// no cap). Refusals count as rejected in Stats. Scheduled and deferred
+// requests (SubmitAt, SubmitDeferred, SubmitErr's retries) are never
+// refused, but they too wait while the Pool is full.
+// Must be called before the Balance Loop is started.
+func (b *Balancer) SetMaxPending(n int, mode OverloadMode) {
+	b.ceiling = max(n, 0)
+	b.reject = mode == OverloadReject
+}
+
+// Full: Whether the Pool is at the SetMaxPending cap.
+func (b *Balancer) full() bool {
+	return b.ceiling > 0 && b.pool.Least().pending >= b.ceiling
+}
+
+// Turned Away: Whether a request just taken in is refused at once: the
+// Pool is full, refusals are on (see OverloadReject) and it has a Handle
+// to be told by. Only called from the Balance Loop.
+func (b *Balancer) turnedAway(req Request) bool {
+	return b.reject && b.full() && b.refuse(req)
+}
+
+// Refuse: Turns a request away without running it (see OverloadReject).
+// Reports false, and does nothing, if it has no Handle to be told by.
+func (b *Balancer) refuse(req Request) bool {
+	if req.h == nil {
+		return false
+	}
+	b.rejected.Add(1)
+	req.h.finish(0, ErrSaturated)
+	go func() { // The Submitter may not be Reading yet
+		select {
+		case req.c <- 0:
+		case <-req.gone:
+		}
+	}()
+	return true
+}
@@ -0,0 +1,56 @@
+package balance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOverloadRejectRefusesHandles(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 2, MaxPending: 2, Overload: OverloadReject})
+	b.Start()
+	defer b.Close()
+	gate := make(chan struct{})
+	var hs []*Handle
+	for i := 0; i < 6; i++ {
+		hs = append(hs, b.SubmitHandle(func() int { <-gate; return 1 }))
+	}
+	if _, err := b.SubmitErr(func() (int, error) { return 1, nil }); !errors.Is(err, ErrSaturated) {
+		t.Fatalf("SubmitErr on a full Pool: %v", err)
+	}
+	close(gate)
+	refused := 0
+	for _, h := range hs {
+		if _, err := h.Wait(context.Background()); errors.Is(err, ErrSaturated) {
+			refused++
+		}
+	}
+	if refused != 2 {
+		t.Fatalf("%d Handles refused, want 2", refused)
+	}
+}
+
+func TestOverloadRejectHoldsPlainRequests(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 2, MaxPending: 1, Overload: OverloadReject})
+	b.Start()
+	defer b.Close()
+	gate := make(chan struct{})
+	var cs []<-chan int
+	for i := 0; i < 6; i++ {
+		cs = append(cs, b.SubmitAsync(func() int { <-gate; return 1 }))
+	}
+	b.Fire(func() int { <-gate; return 1 })
+	time.Sleep(20 * time.Millisecond)
+	for id, n := range b.Pending() {
+		if n > 1 {
+			t.Errorf("worker %d has %d pending, over the cap of 1", id, n)
+		}
+	}
+	close(gate)
+	for _, c := range cs {
+		if v := <-c; v != 1 {
+			t.Fatalf("held request replied %d, want 1", v)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package balance
+
+import "fmt"
+
+// PanicPolicy: What a Worker does when a work function panics.
+type PanicPolicy int32
+
+const (
+	// Recover: Contain the panic. The Worker logs it, replies a zero result
+	// and stays in the Pool serving later requests.
+	Recover PanicPolicy = iota
+	// Propagate: Fail fast. The panic is re-raised with the Worker ID
+	// attached and crashes the process, as an unguarded panic would.
+	Propagate
+)
+
+// SetPanicPolicy: Chooses between resilient (Recover) and fail-fast
+// (Propagate) handling of panicking work functions. The default is
+// Recover, so one bad request cannot take the process down. Safe to call
+// at any time.
+func (b *Balancer) SetPanicPolicy(p PanicPolicy) {
+	b.opts.policy.Store(int32(p))
+}
+
+// PanicError: What a submitter waiting for an error gets when its work
+// function panicked and the panic was recovered: the panic value itself.
+// It matches ErrPanicked (errors.Is).
+type PanicError struct {
+	Value interface{} // What the Work Function panicked with
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("balance: work function panicked: %v", e.Value)
+}
+
+func (e *PanicError) Unwrap() error { return ErrPanicked }
+
+// Catch: Deferred around a work function by the submit paths that report
+// errors. A panic is handed to "report" as a PanicError and then raised
+// again, so the Worker still applies the PanicPolicy.
+func catch(report func(err error)) {
+	if p := recover(); p != nil {
+		report(&PanicError{Value: p})
+		panic(p) // On to Worker.call
+	}
+}
+
+// Call: Runs one work function under the Balancer's PanicPolicy.
+func (w *Worker) call(fn func() int) (n int) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return // No Panic
+		}
+		if PanicPolicy(w.opts.policy.Load()) == Propagate {
+			panic(fmt.Sprintf("%s: worker %d: work function panicked: %v", w.opts.label(), w.id, r))
+		}
+		w.opts.logf("worker %d: recovered panic: %v", w.id, r)
+		n = 0 // Zero Result to the Submitter
+	}()
+	if w.exec != nil {
+		return w.exec(fn) // Worker's own Implementation
+	}
+	return fn()
+}
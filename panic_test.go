@@ -0,0 +1,26 @@
+package balance
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPanicRecoveredByDefault(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 1})
+	b.Start()
+	defer b.Close()
+	if v := b.Submit(func() int { panic("boom") }); v != 0 {
+		t.Fatalf("panicking request replied %d, want 0", v)
+	}
+	_, err := b.SubmitErr(func() (int, error) { panic("boom") })
+	var pe *PanicError
+	if !errors.As(err, &pe) || pe.Value != "boom" || !errors.Is(err, ErrPanicked) {
+		t.Fatalf("got %v, want a PanicError for boom", err)
+	}
+	if v := b.Submit(func() int { return 7 }); v != 7 {
+		t.Fatalf("pool after a panic replied %d, want 7", v)
+	}
+	if b.Size() != 1 {
+		t.Fatalf("pool has %d Workers after the panics, want 1", b.Size())
+	}
+}
@@ -0,0 +1,28 @@
+package balance
+
+// Pause: Suspends dispatching, e.g. for a downstream maintenance window,
+// without dropping any work. The Balance Loop stops taking requests from
+// the intake, so submitters queue up in the intake buffer (or block), and
+// the Scheduler releases nothing; completions are still processed, so
+// the requests already on the Workers finish. Scheduled and deferred
+// requests (SubmitAt, SubmitDeferred, SubmitErr's retries) that fall due
+// meanwhile wait for Resume as well. The Balancer reports Paused until Resume. Only a Running Balancer
+// can be paused; otherwise Pause does nothing. The Balance Loop must be
+// running.
+func (b *Balancer) Pause() {
+	b.do(func() {
+		if b.transit(Running, Paused) {
+			b.paused = true
+		}
+	})
+}
+
+// Resume: Ends a Pause; the held requests are dispatched at once. Calling
+// it when not paused does nothing. The Balance Loop must be running.
+func (b *Balancer) Resume() {
+	b.do(func() {
+		if b.transit(Paused, Running) {
+			b.paused = false
+		}
+	})
+}
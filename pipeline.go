@@ -0,0 +1,52 @@
+package balance
+
+// Pipeline: Chained Balancers.
+// Each Stage has its own Balancer (its own worker pool and backpressure).
+// The results of stage N are handed to stage N+1's function, which runs
+// on stage N+1's pool. Closing the input channel shuts the stages down in
+// order: each stage's output closes once its own in-flight work drained.
+type Pipeline struct {
+	stages []Stage
+}
+
+// Stage Structure: One step of a Pipeline
+type Stage struct {
+	Balancer *Balancer     // Pool running this Stage (loop must be running)
+	Fn       func(int) int // Work applied to each value from the prior Stage
+}
+
+// NewPipeline: Creates a Pipeline from its Stages, first to last.
+func NewPipeline(stages ...Stage) *Pipeline {
+	return &Pipeline{stages: stages}
+}
+
+// Run: Feeds "in" through every Stage and returns the last Stage's output.
+// The first Stage receives the input values themselves.
+func (p *Pipeline) Run(in <-chan int) <-chan int {
+	for _, st := range p.stages {
+		in = st.Balancer.SubmitStream(bind(in, st.Fn))
+	}
+	return in
+}
+
+// Bind: Turns a stream of values into a stream of work functions that
+// apply "fn" to each value. The work stream closes when "in" closes.
+func bind(in <-chan int, fn func(int) int) <-chan func() int {
+	out := make(chan func() int)
+	go func() {
+		for v := range in {
+			out <- func() int { return fn(v) }
+		}
+		close(out)
+	}()
+	return out
+}
+
+// Stats: Per Stage pending statistics, first to last.
+func (p *Pipeline) Stats() []*Stats {
+	s := make([]*Stats, len(p.stages))
+	for i, st := range p.stages {
+		s[i] = st.Balancer.Stats()
+	}
+	return s
+}
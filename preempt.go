@@ -0,0 +1,142 @@
+package balance
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Preemption:
+// With preemption on (SetPreemption), an urgent request that finds no
+// idle Worker may take over a Worker that is running nothing but a
+// lower-priority preemptible request: that request's context is
+// cancelled, the urgent request is queued right behind it, and once the
+// preempted work returns it is queued again from the start.
+//
+// Go cannot stop a running function, so this ONLY works for work that is
+// cooperatively cancellable: a preemptible work function MUST check its
+// context (see CheckCancel) and return promptly once it is done -
+// otherwise the urgent request waits for it to finish anyway. A preempted
+// request runs again from the beginning, so it must also be safe to
+// repeat. Every attempt is counted as a dispatch and a completion.
+
+// Attempt States of a Preemptible Request
+const (
+	attemptRunning   int32 = iota // Running (or Queued)
+	attemptPreempted              // Cancelled by the Balancer: Run it again
+	attemptFinished               // Result Delivered
+)
+
+// Attempt: The current run of a preemptible request - its context and
+// whether it was preempted. Renewed (new context) before each re-queue.
+type attempt struct {
+	mu     sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+	state  atomic.Int32
+}
+
+func (a *attempt) renew() {
+	a.mu.Lock()
+	a.ctx, a.cancel = context.WithCancel(context.Background())
+	a.mu.Unlock()
+	a.state.Store(attemptRunning)
+}
+
+func (a *attempt) current() context.Context {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.ctx
+}
+
+// Stop: Cancels the current run's context.
+func (a *attempt) stop() {
+	a.mu.Lock()
+	cancel := a.cancel
+	a.mu.Unlock()
+	cancel()
+}
+
+// SetPreemption: Turns preemption of running low-priority requests on or
+// off (off by default). Must be called before the Balance Loop is started.
+func (b *Balancer) SetPreemption(on bool) {
+	b.preempt = on
+}
+
+// SubmitPriority: Submits a preemptible, cancellable request with the
+// given priority (higher is more urgent) and returns its Handle. With
+// preemption on, a request of higher priority may preempt it while it
+// runs, and it may preempt running requests of lower priority. The work
+// function must honour its context (see Preemption above). Cancelling the
+// Handle cancels the current run and the request is not run again.
+// Under SetPriorityScheduling the priority also decides which held
+// request is dispatched first.
+func (b *Balancer) SubmitPriority(prio int, fn func(ctx context.Context) int) *Handle {
+	a := &attempt{}
+	a.renew()
+	h := &Handle{done: make(chan struct{}), stop: a.stop}
+	h.worker.Store(-1) // Not Dispatched yet
+	run := func() int {
+		if h.canceled.Load() {
+			return 0 // Cancelled before it Started
+		}
+		defer catch(func(err error) { h.finish(0, err) }) // Panicking: Release the Waiters
+		v := fn(a.current())
+		if !a.state.CompareAndSwap(attemptRunning, attemptFinished) {
+			return 0 // Preempted: The Result is discarded, the Request runs again
+		}
+		h.finish(v, nil)
+		return v
+	}
+	if !hand(b, b.work, Request{fn: run, c: make(chan int, 1), h: h, prio: prio, pre: a}, nil) { // Reply is never read: buffer it
+		h.finish(0, ErrClosed) // Never Submitted
+	}
+	return h
+}
+
+// Victim: A Worker busy with just one preemptible request of priority
+// below "prio" (the lowest such), or nil. Only called from the Balance
+// Loop.
+func (b *Balancer) victim(prio int) *Worker {
+	var best *Worker
+	for _, w := range b.workers {
+		if len(w.jobs) != 1 || w.jobs[0].pre == nil || w.jobs[0].prio >= prio {
+			continue // Queued Work behind it, or not Preemptible
+		}
+		if best == nil || w.jobs[0].prio < best.jobs[0].prio {
+			best = w
+		}
+	}
+	return best
+}
+
+// PreemptFor: Sends an urgent request to a Worker whose only job it
+// preempts, if preemption is on and there is no idle Worker. Returns that
+// Worker, or nil if nothing was preempted. Only called from the Balance
+// Loop.
+func (b *Balancer) preemptFor(req Request) *Worker {
+	if !b.preempt || req.prio <= 0 || b.pool.Least().pending == 0 {
+		return nil // Off, not Urgent, or an Idle Worker will do
+	}
+	w := b.victim(req.prio)
+	if w == nil || !w.spare() || !w.jobs[0].pre.state.CompareAndSwap(attemptRunning, attemptPreempted) {
+		return nil // No Victim (or no Room behind it), or it has just Finished
+	}
+	w.jobs[0].pre.stop() // Tell the running Work to Stop
+	b.assign(w, req)     // Urgent Request goes right behind it
+	b.pool.Adjust(w)
+	return w
+}
+
+// Requeue: Queues a preempted request again (through the Scheduler) with
+// a fresh context, unless its Handle was cancelled meanwhile. Only called
+// from the Balance Loop.
+func (b *Balancer) requeue(j job) {
+	if j.pre == nil || j.pre.state.Load() != attemptPreempted || j.h.canceled.Load() {
+		return
+	}
+	j.pre.renew()
+	req := j.req
+	req.c = make(chan int, 1) // The old Reply Buffer holds the discarded Result
+	b.sched.Push(req)
+}
@@ -0,0 +1,88 @@
+package balance
+
+import (
+	"container/heap"
+	"time"
+)
+
+// Priority Scheduling:
+// With the default Scheduler every request goes straight to a Worker's
+// buffer, so a request's priority (see SubmitPriority) cannot overtake
+// work that arrived before it. The priority Scheduler instead holds the
+// arrived requests in the Balance Loop and releases them only while the
+// least loaded Worker has fewer than "depth" pending, most urgent first;
+// requests of the same priority keep their arrival order. To keep a
+// steady stream of urgent work from starving the rest, a held request
+// gains one priority level for every "age" it waits (0 = No Aging).
+
+// Ranked Structure: A held request with its place in the order
+type ranked struct {
+	req   Request // Request to Dispatch
+	rank  int64   // Priority Levels x Age, less Arrival Time (Higher goes first)
+	order int64   // Arrival Number (FIFO among Equals)
+}
+
+// RankQueue: Max-Heap of held requests (highest rank, then oldest first)
+type rankQueue []ranked
+
+func (q rankQueue) Len() int { return len(q) }
+func (q rankQueue) Less(i, j int) bool {
+	if q[i].rank != q[j].rank {
+		return q[i].rank > q[j].rank
+	}
+	return q[i].order < q[j].order
+}
+func (q rankQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *rankQueue) Push(x interface{}) { *q = append(*q, x.(ranked)) }
+
+func (q *rankQueue) Pop() interface{} {
+	a := *q
+	r := a[len(a)-1]     // Load Removed Element
+	*q = a[0 : len(a)-1] // Shorten the Queue by 1
+	return r
+}
+
+// PriorityScheduler: The Scheduler installed by SetPriorityScheduling.
+type priorityScheduler struct {
+	queue rankQueue   // Held Requests
+	n     int64       // Requests Arrived so far
+	age   int64       // Wait worth one Priority Level (ns, 0 = No Aging)
+	start time.Time   // Arrival Times are taken from here
+	room  func() bool // Whether the Pool takes another Request now
+}
+
+// Push: Ranks the request. Waiting "age" is worth one level, so for any
+// two held requests the order by current priority (level plus levels
+// gained by waiting) never changes - it can be fixed at arrival.
+func (s *priorityScheduler) Push(req Request) {
+	rank := int64(req.prio)
+	if s.age > 0 {
+		rank = rank*s.age - int64(time.Since(s.start))
+	}
+	s.n++
+	heap.Push(&s.queue, ranked{req: req, rank: rank, order: s.n})
+}
+
+func (s *priorityScheduler) Next() (Request, bool) {
+	if len(s.queue) == 0 || !s.room() {
+		return Request{}, false // Nothing Held, or the Pool is Full
+	}
+	return heap.Pop(&s.queue).(ranked).req, true
+}
+
+func (s *priorityScheduler) Len() int { return len(s.queue) }
+
+// SetPriorityScheduling: Dispatches held requests by priority (see
+// Priority Scheduling above), keeping at most "depth" pending on the
+// least loaded Worker (at least 1; 1 hands a request to a Worker only
+// when it is idle, for the strictest order). Replaces the Scheduler.
+// Must be called before the Balance Loop is started.
+func (b *Balancer) SetPriorityScheduling(depth int, age time.Duration) {
+	depth = max(depth, 1)
+	b.sched = &priorityScheduler{
+		age:   int64(age),
+		start: time.Now(),
+		room:  func() bool { return b.pool.Least().pending < depth },
+	}
+}
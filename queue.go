@@ -0,0 +1,107 @@
+package balance
+
+// Queue: The structure that keeps the Workers ordered by load.
+// The Balance Loop only asks for the least loaded Worker and reports
+// pending changes, so any structure with these operations can stand in
+// for the Heap. The default is the exact Heap ("Pool"); very large pools
+// may prefer Buckets. A Queue is owned by the Balance Loop and need not be
+// safe for concurrent use. It orders the Workers by what their ID,
+// Pending and Weight report; a Queue outside the package keeps any
+// bookkeeping of its own (like a position) in a map by Worker.
+type Queue interface {
+	Len() int           // Number of Workers
+	Add(w *Worker)      // Insert a Worker
+	Drop(w *Worker)     // Remove a Worker
+	Least() *Worker     // A Worker with the Lightest Load (stays queued)
+	Adjust(w *Worker)   // Re-position a Worker whose pending changed
+	Workers() []*Worker // All Workers, in no particular order
+}
+
+// SetQueue: Replaces the default Heap with another Queue, moving the
+// existing Workers across. Must be called before the Balance Loop starts.
+func (b *Balancer) SetQueue(q Queue) {
+	for _, w := range b.pool.Workers() {
+		q.Add(w)
+	}
+	b.pool = q
+}
+
+// Buckets: A Queue for thousands of Workers.
+// Workers are filed in buckets by pending count; the least loaded Worker
+// is found by walking up from the lowest bucket in use, and pending only
+// ever changes by small steps, so the walk is short - near O(1) instead of
+// the Heap's O(log n). Within a bucket Workers are in no particular order.
+// Buckets file by the raw pending count: unlike the Heap they ignore
+// Worker weights.
+type Buckets struct {
+	level [][]*Worker     // Workers grouped by pending count
+	at    map[*Worker]int // Bucket each Worker is filed under
+	low   int             // No Worker is filed below this bucket
+}
+
+// NewBuckets: Creates an empty Buckets Queue.
+func NewBuckets() *Buckets {
+	return &Buckets{at: make(map[*Worker]int)}
+}
+
+func (q *Buckets) Len() int { return len(q.at) }
+
+func (q *Buckets) Add(w *Worker) { q.file(w) }
+
+func (q *Buckets) Drop(w *Worker) {
+	if _, ok := q.at[w]; !ok {
+		w.stale("drop") // Not Filed: Nothing to Drop
+		return
+	}
+	q.unfile(w)
+	delete(q.at, w)
+	w.i = -1 // for safety (Non-existant Bucket Index)
+}
+
+func (q *Buckets) Adjust(w *Worker) {
+	if _, ok := q.at[w]; !ok {
+		w.stale("adjust") // Not Filed (Dropped): Leave the Buckets alone
+		return
+	}
+	q.unfile(w)
+	q.file(w)
+}
+
+func (q *Buckets) Least() *Worker {
+	for len(q.level[q.low]) == 0 { // Walk up to the first bucket in use
+		q.low++
+	}
+	return q.level[q.low][0]
+}
+
+func (q *Buckets) Workers() []*Worker {
+	all := make([]*Worker, 0, len(q.at))
+	for _, l := range q.level {
+		all = append(all, l...)
+	}
+	return all
+}
+
+// File: Appends the Worker to the bucket for its current pending count.
+// The Worker's index "i" is its position within that bucket.
+func (q *Buckets) file(w *Worker) {
+	p := max(w.pending, 0)
+	for len(q.level) <= p { // Grow to reach the bucket
+		q.level = append(q.level, nil)
+	}
+	w.i = len(q.level[p])
+	q.level[p] = append(q.level[p], w)
+	q.at[w] = p
+	if p < q.low || len(q.at) == 1 {
+		q.low = p // New Lowest bucket in use
+	}
+}
+
+// Unfile: Removes the Worker from its bucket by moving the bucket's last
+// Worker into its slot.
+func (q *Buckets) unfile(w *Worker) {
+	l := q.level[q.at[w]]
+	last := l[len(l)-1]
+	l[w.i], last.i = last, w.i
+	q.level[q.at[w]] = l[:len(l)-1]
+}
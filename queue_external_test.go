@@ -0,0 +1,62 @@
+package balance_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/godfather667/balance"
+)
+
+// Scan: A Queue built outside the package - an unordered list searched
+// for the least pending load on every call.
+type scan struct {
+	all []*balance.Worker
+}
+
+func (q *scan) Len() int { return len(q.all) }
+
+func (q *scan) Add(w *balance.Worker) { q.all = append(q.all, w) }
+
+func (q *scan) Drop(w *balance.Worker) {
+	q.all = slices.DeleteFunc(q.all, func(x *balance.Worker) bool { return x == w })
+}
+
+func (q *scan) Least() *balance.Worker {
+	best := q.all[0]
+	for _, w := range q.all[1:] {
+		if w.Pending() < best.Pending() || w.Pending() == best.Pending() && w.ID() < best.ID() {
+			best = w
+		}
+	}
+	return best
+}
+
+func (q *scan) Adjust(w *balance.Worker) {} // Nothing Cached
+
+func (q *scan) Workers() []*balance.Worker { return q.all }
+
+func TestExternalQueue(t *testing.T) {
+	b, err := balance.NewBalancerConfig(balance.Config{Workers: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := &scan{}
+	b.SetQueue(q)
+	b.Start()
+	defer b.Close()
+	gate := make(chan struct{})
+	defer close(gate)
+	for i := 0; i < 10; i++ {
+		b.Fire(func() int { <-gate; return 0 })
+	}
+	want := []int{3, 3, 2, 2} // Round by Round, lowest ID first
+	if p := b.Pending(); !slices.Equal(p, want) {
+		t.Fatalf("pending %v, want %v", p, want)
+	}
+	if err := b.RemoveWorker(3); err != nil {
+		t.Fatal(err)
+	}
+	if q.Len() != 3 {
+		t.Fatalf("queue holds %d workers after a removal, want 3", q.Len())
+	}
+}
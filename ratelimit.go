@@ -0,0 +1,58 @@
+package balance
+
+import "time"
+
+// Throttle: The Balance Loop's dispatch rate limit (see SetRateLimit).
+type throttle struct {
+	every time.Duration // Least Time between two Dispatches (0 = Unthrottled)
+	next  time.Time     // Earliest Time of the next Dispatch
+	alarm *time.Timer   // Fires when the next Dispatch is allowed
+}
+
+func (t *throttle) init() {
+	t.alarm = time.NewTimer(time.Hour)
+	t.alarm.Stop() // Unthrottled
+}
+
+// Open: Whether a request may be dispatched now.
+func (t *throttle) open() bool {
+	return t.every == 0 || !time.Now().Before(t.next)
+}
+
+// Take: Counts one dispatch against the limit. Spacing restarts from now
+// after a quiet spell, so idle time never builds up a burst.
+func (t *throttle) take() {
+	if t.every == 0 {
+		return
+	}
+	if now := time.Now(); t.next.Before(now) {
+		t.next = now // Quiet Spell: No Burst
+	}
+	t.next = t.next.Add(t.every)
+}
+
+// Rearm: Points the alarm at the next allowed dispatch (or stops it).
+func (t *throttle) rearm() {
+	if t.every == 0 || t.open() {
+		t.alarm.Stop()
+		return
+	}
+	t.alarm.Reset(time.Until(t.next))
+}
+
+// SetRateLimit: Caps dispatch at "perSec" requests per second, however
+// many Workers have room - to protect a downstream system (0, the
+// default, leaves dispatch unthrottled). Dispatches are spaced evenly,
+// one per 1/perSec, with no bursts. Requests over the limit wait in the
+// intake (and the Scheduler), so submitters block as with a full Pool;
+// the Balance Loop wakes itself when the next dispatch is due, so no
+// extra locking or goroutine is involved. Scheduled and deferred requests
+// (SubmitAt, SubmitDeferred, SubmitErr's retries) count against the limit
+// like any other dispatch.
+// Must be called before the Balance Loop is started.
+func (b *Balancer) SetRateLimit(perSec int) {
+	b.limit.every = 0
+	if perSec > 0 {
+		b.limit.every = time.Second / time.Duration(perSec)
+	}
+}
@@ -0,0 +1,73 @@
+package balance
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimitCapsDispatch(t *testing.T) {
+	const perSec, window = 100, 300 * time.Millisecond
+	b, _ := NewBalancerConfig(Config{Workers: 4})
+	b.SetRateLimit(perSec)
+	b.Start()
+	defer b.Close()
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() { // More Work than the Limit allows
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				b.Fire(func() int { return 0 })
+			}
+		}
+	}()
+	time.Sleep(20 * time.Millisecond) // Past the first Dispatch
+	before := b.Stats().Totals.Dispatched
+	time.Sleep(window)
+	n := b.Stats().Totals.Dispatched - before
+	close(stop)
+	<-done // Before Close: a blocked Fire would panic
+
+	limit := int64(perSec*window/time.Second) + 2 // One at either Edge
+	if n > limit || n < limit/2 {
+		t.Fatalf("%d dispatches in %v, want about %d and at most %d", n, window, limit-2, limit)
+	}
+}
+
+func TestRateLimitCapsRetriesAndDeferred(t *testing.T) {
+	const perSec, window = 100, 300 * time.Millisecond
+	b, _ := NewBalancerConfig(Config{Workers: 4})
+	b.SetRateLimit(perSec)
+	b.SetRetry(Retry{MaxAttempts: 4, Backoff: time.Millisecond})
+	b.Start()
+	defer b.Close()
+	var later []<-chan int
+	for i := 0; i < 30; i++ { // All Due at once
+		later = append(later, b.SubmitDeferred(time.Millisecond, func() int { return 0 }))
+	}
+	failed := make(chan error, 5)
+	for i := 0; i < 5; i++ { // Every Attempt fails: Four Dispatches each
+		go func() {
+			_, err := b.SubmitErr(func() (int, error) { return 0, errors.New("boom") })
+			failed <- err
+		}()
+	}
+	time.Sleep(20 * time.Millisecond) // Past the first Dispatch
+	before := b.Stats().Totals.Dispatched
+	time.Sleep(window)
+	n := b.Stats().Totals.Dispatched - before
+
+	limit := int64(perSec*window/time.Second) + 2 // One at either Edge
+	if n > limit || n < limit/2 {
+		t.Fatalf("%d dispatches in %v, want about %d and at most %d", n, window, limit-2, limit)
+	}
+	for range 5 {
+		<-failed
+	}
+	for _, c := range later {
+		<-c
+	}
+}
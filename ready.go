@@ -0,0 +1,68 @@
+package balance
+
+import "sync/atomic"
+
+// Readiness: Backpressure signalling to producers.
+// Rather than blocking on a send to the Work Channel, a producer can wait
+// on Ready() before generating an expensive request. The channel handed out
+// is CLOSED while the Pool is below the ready level (so every waiter wakes
+// at once) and replaced by a fresh open channel when the Pool fills up.
+type readiness struct {
+	level float64                       // Utilization below which we are Ready
+	ch    atomic.Pointer[chan struct{}] // Current Signal Channel
+	open  bool                          // Ready (current channel is closed)
+}
+
+// Init: Starts in the Ready state with the default level (1.0 = ready
+// while any worker buffer slot is free).
+func (r *readiness) init() {
+	ch := make(chan struct{})
+	close(ch) // Ready: Closed Channel
+	r.ch.Store(&ch)
+	r.level = 1.0
+	r.open = true
+}
+
+// Set: Switches the signal, closing or replacing the channel as needed.
+func (r *readiness) set(ready bool) {
+	if ready == r.open {
+		return // No Transition
+	}
+	r.open = ready
+	if ready {
+		close(*r.ch.Load()) // Wake All Waiters
+		return
+	}
+	ch := make(chan struct{}) // Not Ready: New Open Channel
+	r.ch.Store(&ch)
+}
+
+// SetReadyLevel: Sets the utilization (total pending / total buffer slots,
+// 0 to 1) below which the Balancer reports Ready. Must be called before
+// the Balance Loop is started.
+func (b *Balancer) SetReadyLevel(u float64) {
+	b.ready.level = u
+}
+
+// Ready: Returns a channel that is closed when the Balancer has capacity.
+// Fetch it again after each wake-up; a new channel is issued once the Pool
+// becomes busy again.
+func (b *Balancer) Ready() <-chan struct{} {
+	return *b.ready.ch.Load()
+}
+
+// Utilization: Requests held by the Workers as a fraction of all worker
+// buffer slots (a count of requests, whatever their cost).
+func (b *Balancer) utilization() float64 {
+	held, slots := 0, 0
+	for _, w := range b.pool.Workers() { //Loop thru the Pool
+		held += len(w.jobs)
+		slots += cap(w.requests)
+	}
+	return float64(held) / float64(slots)
+}
+
+// Signal: Re-evaluates readiness. Runs on each Balance Loop event.
+func (b *Balancer) signal() {
+	b.ready.set(b.utilization() < b.ready.level)
+}
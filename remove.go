@@ -0,0 +1,89 @@
+package balance
+
+import "slices"
+
+// RemoveWorker: Takes the Worker with the given stable ID out of the Pool
+// without losing work. No new request is sent to it; the requests still
+// waiting in its buffer are taken back and dispatched to the remaining
+// Workers, and only the request it is running (if any) finishes on it,
+// after which its goroutine exits. Returns ErrNoWorker if no Worker has
+// the ID, ErrLastWorker if it is the last one (the Pool is never left
+// empty), ErrMinWorkers at the floor (see SetPoolBounds) and ErrClosed
+// after Close. The Balance Loop must be running.
+func (b *Balancer) RemoveWorker(id int) error {
+	err := ErrNoWorker
+	if !b.do(func() {
+		w := b.lookup(id)
+		if w == nil {
+			return
+		}
+		if err = b.shrinkable(); err == nil {
+			b.retire(w)
+		}
+	}) {
+		return ErrClosed
+	}
+	return err
+}
+
+// Retire: Removes the Worker and moves its queued requests elsewhere.
+// The Worker takes requests from the head of its buffer, so the requests
+// taken back are the NEWEST of its jobs: all jobs before them are running
+// or finished and stay on its books until it reports them done. Only
+// called from the Balance Loop.
+func (b *Balancer) retire(w *Worker) {
+	for _, req := range b.withdraw(w) { // Re-dispatch in their Original Order
+		b.place(req)
+	}
+}
+
+// Withdraw: The first half of retire - takes the Worker out of the Pool
+// and off the roster and returns its buffered requests, in their original
+// order, for the caller to dispatch. Only called from the Balance Loop.
+func (b *Balancer) withdraw(w *Worker) []Request {
+	b.pool.Drop(w) // No new Requests
+	w.retired = true
+	var moved []Request
+	for taking := true; taking; { // Take back the Buffered Requests
+		select {
+		case req := <-w.requests:
+			moved = append(moved, req)
+		default:
+			taking = false // Buffer Empty
+		}
+	}
+	close(w.requests) // Worker exits after its current Request
+	kept := len(w.jobs) - len(moved)
+	busy := w.pending > 0
+	for _, j := range w.jobs[kept:] { // Taken back: Not this Worker's Load
+		w.pending -= j.cost
+		b.pending -= j.cost
+	}
+	if busy && w.pending == 0 && b.onIdle != nil {
+		b.onIdle(w.id) // Nothing left Running
+	}
+	w.jobs = w.jobs[:kept]
+	b.totals.Dispatched -= int64(len(moved)) // Counted again when Re-dispatched
+	b.workers = slices.DeleteFunc(slices.Clone(b.workers), func(x *Worker) bool { return x == w })
+	b.enroll()
+	return moved
+}
+
+// Enroll: Publishes the current Workers to the roster. The Loop replaces
+// "workers" instead of changing it in place, so a published copy is
+// never written again.
+func (b *Balancer) enroll() {
+	ws := b.workers
+	b.roster.Store(&ws)
+}
+
+// Find: The Worker with the given stable ID, or nil. Safe from any
+// goroutine (through the roster, not the Pool).
+func (b *Balancer) find(id int) *Worker {
+	for _, w := range *b.roster.Load() {
+		if w.id == id {
+			return w
+		}
+	}
+	return nil
+}
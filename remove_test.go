@@ -0,0 +1,48 @@
+package balance
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRemoveWorkerMovesQueuedWork(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 3})
+	b.Start()
+	defer b.Close()
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	b.do(func() { // Worker 0: One Running, Five Queued
+		b.dispatchTo(0, Request{fn: func() int { close(started); <-gate; return 0 }, c: make(chan int, 1)})
+	})
+	<-started
+	var ran [5]atomic.Int64
+	var hs []*Handle
+	b.do(func() {
+		for i := range ran {
+			h := &Handle{done: make(chan struct{})}
+			h.worker.Store(-1)
+			b.dispatchTo(0, Request{fn: h.wrap(func() int { ran[i].Add(1); return i }), c: make(chan int, 1), h: h})
+			hs = append(hs, h)
+		}
+	})
+	if err := b.RemoveWorker(0); err != nil {
+		t.Fatalf("RemoveWorker(0): %v", err)
+	}
+	for i, h := range hs {
+		v, err := h.Wait(context.Background())
+		if err != nil || v != i || h.Worker() == 0 {
+			t.Fatalf("queued request %d: %d, %v on worker %d; want it run elsewhere", i, v, err, h.Worker())
+		}
+	}
+	close(gate) // The Running one finishes on the removed Worker
+	b.WaitIdle(context.Background())
+	for i := range ran {
+		if n := ran[i].Load(); n != 1 {
+			t.Fatalf("queued request %d ran %d times", i, n)
+		}
+	}
+	if b.Size() != 2 || b.HasWorker(0) {
+		t.Fatalf("pool has %d Workers after the removal", b.Size())
+	}
+}
@@ -0,0 +1,55 @@
+package balance
+
+import "time"
+
+// Reply Delivery:
+// A Worker hands each result to the submitter over the request's reply
+// channel. With the default unbuffered channel the Worker waits until the
+// submitter reads, and a submitter that never reads removes that Worker
+// from service for good. Two options guard against that:
+//
+//   - SetReplyBuffer makes the reply channels the Balancer creates
+//     buffered. The Worker drops the result into the buffer and moves on;
+//     an unread result stays in memory until the channel is collected.
+//   - SetReplyTimeout bounds how long a Worker waits on ANY reply channel
+//     (including ones made by the caller). A result not read in time is
+//     dropped and logged - the submitter will never see it.
+//
+// A submitter that knows when it gives up says so instead: a request
+// carrying a "gone" channel has its result dropped as soon as that
+// channel is closed, so a caller that walks away never strands a Worker.
+
+// SetReplyBuffer: Sets the buffer size of the reply channels created by
+// the Balancer's Submit methods (0, the default, is unbuffered).
+func (b *Balancer) SetReplyBuffer(n int) {
+	b.opts.replyBuf.Store(int32(max(n, 0)))
+}
+
+// SetReplyTimeout: Sets how long a Worker waits for a submitter to take
+// its result before dropping it (0, the default, waits forever).
+func (b *Balancer) SetReplyTimeout(d time.Duration) {
+	b.opts.replyWait.Store(int64(d))
+}
+
+// ReplyChan: Makes a reply channel according to the options.
+func (b *Balancer) replyChan() chan int {
+	return make(chan int, b.opts.replyBuf.Load())
+}
+
+// Reply: Delivers a result, giving up if the submitter has gone or after
+// the reply timeout (if any).
+func (w *Worker) reply(req Request, v int) {
+	var expired <-chan time.Time // nil: No Timeout
+	d := time.Duration(w.opts.replyWait.Load())
+	if d > 0 {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		expired = t.C
+	}
+	select {
+	case req.c <- v: // Delivered
+	case <-req.gone: // Submitter stopped Waiting: Drop it quietly
+	case <-expired: // Submitter presumed gone: Drop it
+		w.opts.logf("worker %d: reply not taken within %v, dropped", w.id, d)
+	}
+}
@@ -0,0 +1,44 @@
+package balance
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Recovers: Fails the test unless the single Worker of "b" serves another
+// request within a second.
+func recovers(t *testing.T, b *Balancer) {
+	t.Helper()
+	done := make(chan int, 1)
+	go func() { done <- b.Submit(func() int { return 7 }) }()
+	select {
+	case v := <-done:
+		if v != 7 {
+			t.Fatalf("next request replied %d, want 7", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Worker still stuck on an abandoned reply")
+	}
+}
+
+func TestReplyTimeoutFreesWorker(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 1})
+	b.SetReplyTimeout(10 * time.Millisecond)
+	b.Start()
+	defer b.Close()
+	b.send(Request{fn: func() int { return 1 }, c: make(chan int)}) // Reply never read
+	recovers(t, b)
+}
+
+func TestAbandonedContextFreesWorker(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 1})
+	b.Start()
+	defer b.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	gone := make(chan struct{})
+	b.send(Request{fn: func() int { <-gone; return 1 }, c: make(chan int), gone: ctx.Done()})
+	cancel() // Caller walks away
+	close(gone)
+	recovers(t, b)
+}
@@ -0,0 +1,40 @@
+package balance
+
+import "time"
+
+// Report Structure: An end-of-life summary of a Balancer's work, for batch
+// jobs and tests to log or assert on when they are done with the Pool.
+// The counters cover the same span as Totals (since start or the last
+// ResetStats); Uptime always runs from NewBalancer.
+type Report struct {
+	Name        string        `json:"name,omitempty"` // Balancer Name
+	Processed   int64         `json:"processed"`      // Requests Completed
+	Rejected    int64         `json:"rejected"`       // Requests Refused Admission
+	PeakPending int           `json:"peak_pending"`   // Highest Total Pending Load seen
+	MaxLatency  time.Duration `json:"max_latency_ns"` // Slowest Dispatch to Completion
+	Uptime      time.Duration `json:"uptime_ns"`      // Time since NewBalancer
+}
+
+// Report: The summary as of now. It is taken inside the Balance Loop, so
+// all figures describe the same moment. The Balance Loop must be running;
+// after Close it is the summary taken as the Balancer shut down.
+func (b *Balancer) Report() Report {
+	if f := b.final.Load(); f != nil {
+		return *f // Closed: No Loop to ask
+	}
+	var r Report
+	b.do(func() { r = b.report() })
+	return r
+}
+
+// Report: Builds the summary. Only called from the Balance Loop.
+func (b *Balancer) report() Report {
+	return Report{
+		Name:        b.opts.name,
+		Processed:   b.totals.Completed,
+		Rejected:    b.rejected.Load(),
+		PeakPending: b.peak,
+		MaxLatency:  b.worst,
+		Uptime:      time.Since(b.born),
+	}
+}
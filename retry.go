@@ -0,0 +1,32 @@
+package balance
+
+import (
+	"errors"
+	"time"
+)
+
+// Retry Structure: How SubmitErr retries a failed request (see SetRetry).
+type Retry struct {
+	MaxAttempts int           // Attempts in all, the first included (1 or less = No Retries)
+	Backoff     time.Duration // Wait before each Retry
+}
+
+// SetRetry: Makes SubmitErr retry a request whose function returns an
+// error, up to "r.MaxAttempts" attempts in all, waiting "r.Backoff"
+// before each retry. A retry is a new request: it is held as deferred
+// work (see SubmitDeferred) and then dispatched to the least loaded
+// Worker, which may not be the one that failed, and every attempt is
+// counted and released as a request of its own. Only the error of the
+// last attempt reaches the caller. Panics (PanicError), refusals
+// (ErrSaturated), cancellations (ErrCanceled) and a closed Balancer
+// (ErrClosed) are not retried; Close fails a retry still in its backoff
+// with ErrClosed. The zero Retry, the default, never retries.
+// Must be called before the Balance Loop is started.
+func (b *Balancer) SetRetry(r Retry) {
+	b.retries = r
+}
+
+// Retryable: Whether a failed attempt may be tried again.
+func retryable(err error) bool {
+	return err != nil && !errors.Is(err, ErrPanicked) && !errors.Is(err, ErrSaturated) && !errors.Is(err, ErrCanceled) && !errors.Is(err, ErrClosed)
+}
@@ -0,0 +1,129 @@
+package balance
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSubmitErrRetries(t *testing.T) {
+	b := NewBalancer()
+	b.SetRetry(Retry{MaxAttempts: 3, Backoff: 10 * time.Millisecond})
+	b.Start()
+	defer b.Close()
+	boom := errors.New("boom")
+	var n atomic.Int64
+	v, err := b.SubmitErr(func() (int, error) {
+		if n.Add(1) < 3 {
+			return 0, boom
+		}
+		return 7, nil
+	})
+	if v != 7 || err != nil || n.Load() != 3 {
+		t.Fatalf("got %d, %v after %d attempts; want 7, nil after 3", v, err, n.Load())
+	}
+}
+
+func TestCloseFailsRetryInBackoff(t *testing.T) {
+	b := NewBalancer()
+	b.SetRetry(Retry{MaxAttempts: 2, Backoff: time.Hour})
+	b.Start()
+	failed := make(chan struct{})
+	out := make(chan error, 1)
+	go func() {
+		_, err := b.SubmitErr(func() (int, error) {
+			defer close(failed)
+			return 0, errors.New("boom")
+		})
+		out <- err
+	}()
+	<-failed
+	time.Sleep(10 * time.Millisecond) // Into the Backoff
+	b.Close()
+	select {
+	case err := <-out:
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("got %v, want ErrClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("SubmitErr still waiting for its retry after Close")
+	}
+}
+
+func TestSubmitErrStuck(t *testing.T) {
+	b := NewBalancer()
+	b.SetStuckTimeout(10 * time.Millisecond)
+	b.Start()
+	defer b.Close()
+	release := make(chan struct{})
+	defer close(release)
+	out := make(chan error, 1)
+	go func() {
+		_, err := b.SubmitErr(func() (int, error) { <-release; return 0, nil })
+		out <- err
+	}()
+	select {
+	case err := <-out:
+		if !errors.Is(err, ErrStuck) {
+			t.Fatalf("got %v, want ErrStuck", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SubmitErr still waiting after its Worker was evicted")
+	}
+}
+
+func TestReleasedWorkWaitsForResume(t *testing.T) {
+	b := NewBalancer()
+	b.SetRetry(Retry{MaxAttempts: 2, Backoff: 10 * time.Millisecond})
+	b.Start()
+	defer b.Close()
+	var ran atomic.Int64
+	failed := make(chan struct{})
+	retried := make(chan error, 1)
+	go func() {
+		var n atomic.Int64
+		_, err := b.SubmitErr(func() (int, error) {
+			if n.Add(1) == 1 {
+				defer close(failed)
+				return 0, errors.New("boom")
+			}
+			ran.Add(1)
+			return 0, nil
+		})
+		retried <- err
+	}()
+	<-failed
+	b.Pause() // Before the Retry is Due
+	at := b.SubmitAfter(10*time.Millisecond, func() int { ran.Add(1); return 0 })
+	later := b.SubmitDeferred(10*time.Millisecond, func() int { ran.Add(1); return 0 })
+	time.Sleep(100 * time.Millisecond)
+	if n := ran.Load(); n != 0 {
+		t.Fatalf("%d released requests ran while paused", n)
+	}
+	b.Resume()
+	soon(t, "retry", func() {
+		if err := <-retried; err != nil {
+			t.Errorf("retry: %v", err)
+		}
+	})
+	soon(t, "SubmitAfter", func() { <-at })
+	soon(t, "SubmitDeferred", func() { <-later })
+}
+
+func TestReleasedWorkWaitsForRoom(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 1, MaxPending: 1})
+	b.Start()
+	defer b.Close()
+	gate := make(chan struct{})
+	b.Fire(func() int { <-gate; return 0 })
+	at := b.SubmitAfter(10*time.Millisecond, func() int { return 1 })
+	later := b.SubmitDeferred(10*time.Millisecond, func() int { return 2 })
+	time.Sleep(100 * time.Millisecond)
+	if p := b.Pending(); p[0] != 1 {
+		t.Fatalf("pending %v: released requests went past the cap of 1", p)
+	}
+	close(gate)
+	soon(t, "SubmitAfter", func() { <-at })
+	soon(t, "SubmitDeferred", func() { <-later })
+}
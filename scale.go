@@ -0,0 +1,94 @@
+package balance
+
+import "errors"
+
+// ErrLastWorker: The Pool's only Worker cannot be removed.
+var ErrLastWorker = errors.New("balance: cannot remove the last worker")
+
+// ErrMinWorkers / ErrMaxWorkers: The Pool is at its configured floor or
+// ceiling (see SetPoolBounds), so it cannot shrink or grow any further.
+var (
+	ErrMinWorkers = errors.New("balance: pool at its minimum size")
+	ErrMaxWorkers = errors.New("balance: pool at its maximum size")
+)
+
+// ErrNoWorker: No Worker in the Pool has the given ID.
+var ErrNoWorker = errors.New("balance: no such worker")
+
+// SetPoolBounds: Sets the fewest and the most Workers the Pool may have
+// at run time. AddWorker refuses to grow it beyond "most" (0, the default,
+// sets no ceiling), RemoveWorker and RemoveLeastLoaded refuse to shrink
+// it below "least" (never below 1, the default). Capacity reports the
+// ceiling. Reports false, and changes nothing, if the current Pool is
+// outside the bounds or they cross. Must be called before the Balance
+// Loop is started.
+func (b *Balancer) SetPoolBounds(least, most int) bool {
+	least = max(least, 1)
+	n := b.pool.Len()
+	if n < least || most > 0 && (most < n || most < least) {
+		return false
+	}
+	b.min, b.max = least, max(most, 0)
+	return true
+}
+
+// Shrinkable: Why the Pool may not lose a Worker (nil if it may). Only
+// called from the Balance Loop.
+func (b *Balancer) shrinkable() error {
+	switch n := b.pool.Len(); {
+	case n == 1:
+		return ErrLastWorker // Dispatch always has somewhere to go
+	case n <= b.min:
+		return ErrMinWorkers
+	}
+	return nil
+}
+
+// AddWorker: Grows the Pool by one Worker at run time and returns its
+// stable ID (IDs are never reused). The Worker joins inside the Balance
+// Loop, so the very next dispatch may go to it. At the ceiling (see
+// SetPoolBounds) nothing is added and ErrMaxWorkers is returned, after
+// Close ErrClosed. The Balance Loop must be running.
+func (b *Balancer) AddWorker() (int, error) {
+	return b.AddWeightedWorker(1)
+}
+
+// AddWeightedWorker: AddWorker for a Worker of the given weight (see
+// SetWeight; a weight below 1 counts as 1).
+func (b *Balancer) AddWeightedWorker(weight int) (int, error) {
+	id, err := -1, ErrMaxWorkers
+	if !b.do(func() {
+		if b.max > 0 && b.pool.Len() >= b.max {
+			return
+		}
+		w := b.spawn(nil)
+		w.weight = max(weight, 1)
+		b.pool.Adjust(w) // Re-position for its Capacity
+		id, err = w.id, nil
+	}) {
+		return -1, ErrClosed
+	}
+	return id, err
+}
+
+// RemoveLeastLoaded: Shrinks the Pool by one Worker at run time - the
+// least loaded one, the cheapest to take out - and returns its stable ID.
+// It is retired as by RemoveWorker: its buffered requests move to the
+// other Workers and its goroutine exits once its running request is done.
+// The last Worker is never removed; ErrLastWorker is returned instead, so
+// dispatch always has somewhere to go, and ErrMinWorkers at the floor
+// (see SetPoolBounds). The Balance Loop must be running.
+func (b *Balancer) RemoveLeastLoaded() (int, error) {
+	id, err := -1, error(nil)
+	if !b.do(func() {
+		if err = b.shrinkable(); err != nil {
+			return
+		}
+		w := b.pool.Least()
+		b.retire(w)
+		id = w.id
+	}) {
+		return -1, ErrClosed
+	}
+	return id, err
+}
@@ -0,0 +1,69 @@
+package balance
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPoolBounds(t *testing.T) {
+	b, err := NewBalancerConfig(Config{Workers: 3, MinWorkers: 2, MaxWorkers: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Start()
+	defer b.Close()
+	if b.Capacity() != 4 || b.Stats().Minimum != 2 {
+		t.Fatalf("capacity %d, minimum %d; want the configured 4 and 2", b.Capacity(), b.Stats().Minimum)
+	}
+	id, err := b.AddWorker()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.AddWorker(); !errors.Is(err, ErrMaxWorkers) {
+		t.Fatalf("AddWorker beyond the ceiling: %v", err)
+	}
+	if err := b.RemoveWorker(id); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.RemoveWorker(id); !errors.Is(err, ErrNoWorker) {
+		t.Fatalf("RemoveWorker of a removed Worker: %v", err)
+	}
+	if _, err := b.RemoveLeastLoaded(); err != nil {
+		t.Fatal(err)
+	}
+	var left int
+	b.do(func() { left = b.workers[0].id })
+	if err := b.RemoveWorker(left); !errors.Is(err, ErrMinWorkers) {
+		t.Fatalf("RemoveWorker below the floor: %v", err)
+	}
+	if _, err := b.RemoveLeastLoaded(); !errors.Is(err, ErrMinWorkers) {
+		t.Fatalf("RemoveLeastLoaded below the floor: %v", err)
+	}
+	if b.Size() != 2 {
+		t.Fatalf("size %d, want the floor of 2", b.Size())
+	}
+	if ids := b.SwapWorkers(func(int) Executor { return nil }, 5); ids != nil {
+		t.Fatalf("SwapWorkers beyond the ceiling brought up %v", ids)
+	}
+}
+
+func TestPoolBoundsConfig(t *testing.T) {
+	for _, cfg := range []Config{
+		{Workers: 3, MaxWorkers: 2},
+		{Workers: 3, MinWorkers: 4},
+		{Workers: 3, MinWorkers: 3, MaxWorkers: 2},
+	} {
+		if _, err := NewBalancerConfig(cfg); err == nil {
+			t.Errorf("%+v accepted", cfg)
+		}
+	}
+	b := New()
+	defer b.Close()
+	if _, err := b.AddWorker(); err != nil || b.Capacity() != 0 {
+		t.Fatalf("unbounded Pool: AddWorker %v, capacity %d", err, b.Capacity())
+	}
+	b.Close()
+	if _, err := b.AddWorker(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("AddWorker after Close: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package balance
+
+import (
+	"container/heap"
+	"time"
+)
+
+// Scheduled Requests:
+// A delayed request is held by the Balance Loop in a min-heap ordered by
+// fire time and released into dispatch when due. Until then it is NOT
+// counted as pending on any Worker.
+type delayed struct {
+	at  time.Time // Fire Time
+	req Request   // Request to Dispatch when Due
+}
+
+// DelayQueue: Min-Heap of delayed requests (earliest first)
+type delayQueue []delayed
+
+func (q delayQueue) Len() int           { return len(q) }
+func (q delayQueue) Less(i, j int) bool { return q[i].at.Before(q[j].at) }
+func (q delayQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *delayQueue) Push(x interface{}) { *q = append(*q, x.(delayed)) }
+
+func (q *delayQueue) Pop() interface{} {
+	a := *q
+	d := a[len(a)-1]     // Load Removed Element
+	*q = a[0 : len(a)-1] // Shorten the Queue by 1
+	return d
+}
+
+// Timetable: The Balance Loop's scheduling state
+type timetable struct {
+	in    chan delayed // Intake for Scheduled Requests
+	queue delayQueue   // Held Requests (Owned by the Balance Loop)
+	alarm *time.Timer  // Fires when the earliest Request is Due
+}
+
+func (t *timetable) init() {
+	t.in = make(chan delayed)
+	t.alarm = time.NewTimer(time.Hour)
+	t.alarm.Stop() // Nothing Scheduled
+}
+
+// Rearm: Points the alarm at the earliest held request (or stops it).
+func (t *timetable) rearm() {
+	if len(t.queue) == 0 {
+		t.alarm.Stop()
+		return
+	}
+	t.alarm.Reset(time.Until(t.queue[0].at))
+}
+
+// Expired: Removes and returns every held request whose time has come.
+func (t *timetable) expired() []Request {
+	var due []Request
+	now := time.Now()
+	for len(t.queue) > 0 && !t.queue[0].at.After(now) {
+		due = append(due, heap.Pop(&t.queue).(delayed).req)
+	}
+	return due
+}
+
+// Release: Queues every held request whose time has come for dispatch.
+func (b *Balancer) release() {
+	for _, req := range b.later.expired() {
+		b.place(req)
+	}
+}
+
+// SubmitAt: Schedules "fn" to be dispatched at time "t" (immediately if
+// "t" has passed). The result is delivered on the returned channel, which
+// is buffered so the Worker never waits for the reader.
+func (b *Balancer) SubmitAt(t time.Time, fn func() int) <-chan int {
+	req := Request{fn: fn, c: make(chan int, 1)} // Buffered Reply Channel
+	if !hand(b, b.later.in, delayed{t, req}, nil) {
+		panic(ErrClosed) // As Submit
+	}
+	return req.c
+}
+
+// SubmitAfter: Schedules "fn" to be dispatched after duration "d".
+func (b *Balancer) SubmitAfter(d time.Duration, fn func() int) <-chan int {
+	return b.SubmitAt(time.Now().Add(d), fn)
+}
@@ -0,0 +1,102 @@
+package balance
+
+import "slices"
+
+// Scheduler: Decides which arrived request is dispatched next.
+// It sits between the intake (the "Work" channel the Submit methods feed)
+// and the Strategy: the Scheduler chooses WHICH request goes next and
+// WHEN, the Strategy then chooses the Worker it goes to. Coalescing,
+// deduplication, priority ordering or per-tenant fairness are all
+// Schedulers.
+//
+// Push is called for every request taken from the intake. After every
+// event the Balance Loop handles (and on every heartbeat tick) it calls
+// Next repeatedly, dispatching each request returned, until Next reports
+// false. A Scheduler may hold requests back (to collect duplicates, or
+// until the Balancer is Ready) by reporting false; those requests are not
+// pending and not yet on any Worker. Scheduled and deferred requests
+// (SubmitAt, SubmitDeferred) bypass the Scheduler, but not Pause, the
+// pending cap or the rate limit (see schedule). Like the Strategy, a
+// Scheduler runs on the Balance Loop and need not lock.
+type Scheduler interface {
+	Push(req Request)      // A Request arrived
+	Next() (Request, bool) // The Request to Dispatch now, if any
+	Len() int              // Requests Held
+}
+
+// Fifo: The default Scheduler - pass everything through in arrival order.
+type fifo struct {
+	q []Request // Arrived, not yet Dispatched
+}
+
+func (s *fifo) Push(req Request) { s.q = append(s.q, req) }
+
+func (s *fifo) Next() (Request, bool) {
+	if len(s.q) == 0 {
+		return Request{}, false
+	}
+	req := s.q[0]
+	s.q[0] = Request{} // Let the Request be Collected
+	s.q = s.q[1:]
+	return req, true
+}
+
+func (s *fifo) Len() int { return len(s.q) }
+
+// SetScheduler: Replaces the default pass-through FIFO Scheduler.
+// Must be called before the Balance Loop is started.
+func (b *Balancer) SetScheduler(s Scheduler) {
+	b.sched = s
+}
+
+// Schedule: Dispatches the backlog, then whatever the Scheduler releases,
+// while the Pool has room (see SetMaxPending) and the rate limit allows
+// (see SetRateLimit), unless the Balancer is paused (see Pause). A request
+// whose Worker has no free slot in its buffer waits at the head of the
+// backlog, and the intake is held until a slot frees up: the queue depth
+// pushes back on the producers as a full Pool does. Returns how many
+// requests were dispatched.
+func (b *Balancer) schedule() int {
+	n := 0
+	b.stalled = false
+	for !b.paused && !b.full() && b.limit.open() { // Full: Held until a Worker has Room
+		req, ok := b.next()
+		if !ok {
+			break
+		}
+		if b.dispatch(req) == nil {
+			b.backlog = slices.Insert(b.backlog, 0, req) // Buffer Full: Next in Line
+			b.stalled = true
+			break
+		}
+		b.limit.take()
+		n++
+	}
+	return n
+}
+
+// Next: The oldest request of the backlog, or else the Scheduler's next.
+func (b *Balancer) next() (Request, bool) {
+	if len(b.backlog) > 0 {
+		req := b.backlog[0]
+		b.backlog[0] = Request{} // Let the Request be Collected
+		b.backlog = b.backlog[1:]
+		return req, true
+	}
+	return b.sched.Next()
+}
+
+// Place: Queues a request the Balance Loop moves on its own (released,
+// deferred or taken back from a Worker) in the backlog. It is dispatched
+// by the next schedule - ahead of the Scheduler, but through the same
+// gates, so Pause, the pending cap and the rate limit hold it too. Only
+// called from the Balance Loop.
+func (b *Balancer) place(req Request) {
+	b.backlog = append(b.backlog, req)
+}
+
+// Held: Requests taken in but not yet dispatched - in the backlog or
+// held by the Scheduler.
+func (b *Balancer) held() int {
+	return len(b.backlog) + b.sched.Len()
+}
@@ -0,0 +1,149 @@
+package balance
+
+import (
+	"errors"
+	"hash/fnv"
+	"sync/atomic"
+	"time"
+)
+
+// ShardedBalancer: Several independent Balancers behind one front door.
+// A single Balance Loop serializes every dispatch and completion, so at
+// very high request rates the loop - not the Workers - is the limit. Each
+// shard has its own Pool and its own loop; requests are routed to a shard
+// round-robin (or by key hash), and each shard then picks its own least
+// loaded Worker. Balance is exact within a shard but only approximate
+// across shards: throughput is bought with some global precision.
+type ShardedBalancer struct {
+	shards []*Balancer
+	next   atomic.Uint64 // Round-Robin Counter
+}
+
+// NewShardedBalancer: Creates "n" shards and starts their Balance Loops.
+func NewShardedBalancer(n int) *ShardedBalancer {
+	s := &ShardedBalancer{shards: make([]*Balancer, max(n, 1))}
+	for i := range s.shards {
+		s.shards[i] = NewBalancer()
+		go s.shards[i].balance() // Each Shard has its own Loop
+	}
+	return s
+}
+
+// Close: Closes every shard (see Balancer.Close), running the work each
+// one has in hand, and returns their errors joined. Afterwards Submit and
+// SubmitKey panic with ErrClosed, as a closed Balancer's Submit does.
+func (s *ShardedBalancer) Close() error {
+	errs := make([]error, len(s.shards))
+	for i, b := range s.shards {
+		errs[i] = b.Close()
+	}
+	return errors.Join(errs...)
+}
+
+// Submit: Runs "fn" on the next shard (round-robin) and waits for it.
+func (s *ShardedBalancer) Submit(fn func() int) int {
+	n := s.next.Add(1) - 1
+	return s.shards[n%uint64(len(s.shards))].run(fn)
+}
+
+// SubmitKey: Runs "fn" on the shard owning "key", so requests with the
+// same key always share a shard.
+func (s *ShardedBalancer) SubmitKey(key string, fn func() int) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return s.shards[h.Sum32()%uint32(len(s.shards))].run(fn)
+}
+
+// Run: Submits "fn" to this Balancer and waits for its reply.
+func (b *Balancer) run(fn func() int) int {
+	req := Request{fn: fn, c: b.replyChan()} // Request with its own Reply Channel
+	b.send(req)                              // Push Request into "Work" Channel
+	return <-req.c                           // Wait for "Done" Reply
+}
+
+// Stats: One Snapshot covering every shard (see rollUp).
+func (s *ShardedBalancer) Stats() *Stats {
+	parts := make([]*Stats, len(s.shards))
+	for i, b := range s.shards {
+		parts[i] = b.Stats()
+	}
+	return rollUp(parts[0].Strategy, parts)
+}
+
+// Load: Total pending load across the shards.
+func (s *ShardedBalancer) Load() int {
+	n := 0
+	for _, b := range s.shards {
+		n += b.Load()
+	}
+	return n
+}
+
+// Run: Submits "fn" to the next shard and waits for it (see SubPool).
+func (s *ShardedBalancer) run(fn func() int) int {
+	return s.Submit(fn)
+}
+
+// PoolStride: Worker IDs of the i-th Pool in a roll-up start at i times
+// this, so Worker "n" of Pool 2 is 2*poolStride+n.
+const poolStride = 1 << 16
+
+// RollUp: Combines Snapshots of several Pools into one. Worker IDs are
+// offset by the Pool's index times a stride (see poolStride) so they stay
+// unique and keep their place as Workers come and go; a Pool that is
+// itself a roll-up gets a wider stride. The average and variance are
+// taken over all Workers. Capacity is the sum of the ceilings, or 0 (No
+// Limit) if any Pool has none.
+func rollUp(strategy string, parts []*Stats) *Stats {
+	all := &Stats{Strategy: strategy}
+	var sum, sumsq float64
+	var total int64 // Loop time summed over the Pools (ns)
+	stride, unlimited := poolStride, false
+	for _, st := range parts {
+		for _, w := range st.Workers {
+			for w.ID >= stride {
+				stride *= poolStride // Nested Roll-up: Widen
+			}
+		}
+	}
+	for i, st := range parts {
+		for _, w := range st.Workers {
+			w.ID += i * stride // Offset by the Pool's Index
+			all.Workers = append(all.Workers, w)
+			load := float64(w.Pending) / float64(max(w.Weight, 1)) // Normalized as in spread
+			sum += load
+			sumsq += load * load
+		}
+		all.Pending += st.Pending
+		all.Deferred += st.Deferred
+		all.Memory += st.Memory
+		all.Active += st.Active
+		all.Dropped += st.Dropped
+		all.Size += st.Size
+		all.Capacity += st.Capacity
+		all.Minimum += st.Minimum
+		unlimited = unlimited || st.Capacity == 0
+		all.Totals.Dispatched += st.Totals.Dispatched
+		all.Totals.Completed += st.Totals.Completed
+		all.Totals.Rejected += st.Totals.Rejected
+		all.Totals.Stuck += st.Totals.Stuck
+		all.Throughput.Window = st.Throughput.Window // Same for every Pool
+		all.Throughput.Dispatched += st.Throughput.Dispatched
+		all.Throughput.Completed += st.Throughput.Completed
+		all.Loop.Count += st.Loop.Count
+		all.Loop.Max = max(all.Loop.Max, st.Loop.Max)
+		total += int64(st.Loop.Mean) * st.Loop.Count
+	}
+	if unlimited {
+		all.Capacity = 0 // Some Pool may grow without Bound
+	}
+	if n := float64(len(all.Workers)); n > 0 {
+		all.Average = sum / n
+		all.Variance = sumsq/n - all.Average*all.Average
+		all.Imbalance = imbalance(all.Average, all.Variance)
+	}
+	if all.Loop.Count > 0 {
+		all.Loop.Mean = time.Duration(total / all.Loop.Count)
+	}
+	return all
+}
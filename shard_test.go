@@ -0,0 +1,89 @@
+package balance
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestShardedClose(t *testing.T) {
+	s := NewShardedBalancer(3)
+	for i := range 6 {
+		s.Submit(func() int { return i })
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	for i, b := range s.shards {
+		if b.State() != Stopped {
+			t.Fatalf("shard %d is %v after Close, want Stopped", i, b.State())
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+}
+
+func TestRollUpWorkerIDs(t *testing.T) {
+	s := NewShardedBalancer(2)
+	defer s.Close()
+	if err := s.shards[1].RemoveWorker(0); err != nil {
+		t.Fatalf("RemoveWorker: %v", err)
+	}
+	var ids []int
+	for _, w := range s.Stats().Workers {
+		ids = append(ids, w.ID)
+	}
+	var want []int
+	for id := range s.shards[0].Size() {
+		want = append(want, id)
+	}
+	for id := range s.shards[1].Size() {
+		want = append(want, poolStride+id+1) // Shard 1 lost Worker 0
+	}
+	if !slices.Equal(ids, want) {
+		t.Fatalf("rolled-up IDs %v, want %v", ids, want)
+	}
+	if st := s.Stats(); st.Capacity != 0 {
+		t.Fatalf("rolled-up Capacity %d, want 0 (No Limit)", st.Capacity)
+	}
+
+	// A Hierarchy over the shards nests one roll-up in another
+	h := NewHierarchy(s, s.shards[0])
+	seen := map[int]bool{}
+	for _, w := range h.Stats().Workers {
+		if seen[w.ID] {
+			t.Fatalf("nested roll-up repeats Worker ID %d", w.ID)
+		}
+		seen[w.ID] = true
+	}
+}
+
+// BenchmarkShards: Parallel submitters through one Balance Loop versus a
+// ShardedBalancer of several loops. The requests do no work, so the loop
+// itself is what is measured.
+func BenchmarkShards(b *testing.B) {
+	fn := func() int { return 0 }
+	b.Run("single", func(b *testing.B) {
+		bal := New()
+		defer bal.Close()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				bal.run(fn)
+			}
+		})
+	})
+	for _, n := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("shards=%d", n), func(b *testing.B) {
+			s := NewShardedBalancer(n)
+			defer s.Close()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					s.Submit(fn)
+				}
+			})
+		})
+	}
+}
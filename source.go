@@ -0,0 +1,72 @@
+package balance
+
+import "sync"
+
+// Source: One submitter's ordered stream. Requests submitted through the
+// same Source run in parallel like any others, but their results come
+// out of Results in submission order: a result that finishes early is
+// held until every earlier one has been delivered. Different Sources are
+// independent, so several ordered streams can share a Balancer.
+type Source struct {
+	id    string
+	b     *Balancer
+	order chan chan int // Reply Channels in Submission order
+	out   chan int      // Results in Submission order
+}
+
+// Source Registry: Sources by ID, so every part of a program that tags
+// its requests with the same ID feeds the same ordered stream.
+type sources struct {
+	mu sync.Mutex
+	m  map[string]*Source
+}
+
+// Source: The ordered stream with the given ID, created on first use.
+func (b *Balancer) Source(id string) *Source {
+	b.srcs.mu.Lock()
+	defer b.srcs.mu.Unlock()
+	if s, ok := b.srcs.m[id]; ok {
+		return s
+	}
+	if b.srcs.m == nil {
+		b.srcs.m = make(map[string]*Source)
+	}
+	s := &Source{id: id, b: b, order: make(chan chan int, nRequester), out: make(chan int)}
+	b.srcs.m[id] = s
+	go s.deliver()
+	return s
+}
+
+// Deliver: Forwards the results in submission order: waits for each
+// request's reply in turn, however early the later ones finished.
+func (s *Source) deliver() {
+	for c := range s.order {
+		s.out <- <-c
+	}
+	close(s.out)
+}
+
+// Submit: Submits "fn" as the next request of this Source. Its result
+// arrives on Results after those of all earlier submissions. Blocks if
+// the consumer of Results falls far behind.
+func (s *Source) Submit(fn func() int) {
+	c := make(chan int, 1)          // Buffered: Workers never wait for the Order
+	s.b.send(Request{fn: fn, c: c}) // Push Request into "Work" Channel
+	s.order <- c                    // Take its Place in Line
+}
+
+// Results: The results of this Source's requests, in submission order.
+// Closed after Close once every result has been delivered.
+func (s *Source) Results() <-chan int {
+	return s.out
+}
+
+// Close: Ends the stream; submitting afterwards panics. Results closes
+// once the outstanding results are delivered, and the ID is free for a
+// new Source.
+func (s *Source) Close() {
+	s.b.srcs.mu.Lock()
+	delete(s.b.srcs.m, s.id)
+	s.b.srcs.mu.Unlock()
+	close(s.order)
+}
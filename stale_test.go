@@ -0,0 +1,59 @@
+package balance
+
+import (
+	"bytes"
+	"container/heap"
+	"log"
+	"strings"
+	"testing"
+)
+
+// Queued: Three idle Workers in "q", logging to "out".
+func queued(q Queue, out *bytes.Buffer) []*Worker {
+	o := &options{logger: log.New(out, "", 0)}
+	var ws []*Worker
+	for i := 0; i < 3; i++ {
+		w := &Worker{id: i, weight: 1, opts: o}
+		q.Add(w)
+		ws = append(ws, w)
+	}
+	return ws
+}
+
+func TestPoolStaleIndex(t *testing.T) {
+	var out bytes.Buffer
+	p := &Pool{}
+	queued(p, &out)
+	gone := heap.Pop(p).(*Worker) // Index -1
+	p.Drop(gone)
+	p.Adjust(gone)
+	kept := (*p)[0]
+	gone.i = kept.i // Index of another Worker
+	p.Drop(gone)
+	if p.Len() != 2 || !p.holds(kept) {
+		t.Fatalf("stale Drop changed the Heap: %d Workers left", p.Len())
+	}
+	p.Drop(kept)
+	p.Drop(kept) // Already Dropped
+	if p.Len() != 1 {
+		t.Fatalf("%d Workers left, want 1", p.Len())
+	}
+	if n := strings.Count(out.String(), "stale index"); n != 4 {
+		t.Fatalf("%d stale operations logged, want 4:\n%s", n, out.String())
+	}
+}
+
+func TestBucketsStaleWorker(t *testing.T) {
+	var out bytes.Buffer
+	q := NewBuckets()
+	ws := queued(q, &out)
+	q.Drop(ws[1])
+	q.Drop(ws[1])
+	q.Adjust(ws[1])
+	if q.Len() != 2 {
+		t.Fatalf("%d Workers left, want 2", q.Len())
+	}
+	if n := strings.Count(out.String(), "stale index"); n != 2 {
+		t.Fatalf("%d stale operations logged, want 2:\n%s", n, out.String())
+	}
+}
@@ -0,0 +1,57 @@
+package balance
+
+// State: Where the Balancer is in its lifecycle.
+//
+// Valid transitions:
+//
+//	Running  -> Paused    (dispatching suspended, intake held)
+//	Paused   -> Running
+//	Running  -> Draining  (waiting for the Pool to go idle)
+//	Paused   -> Draining
+//	Draining -> Running   (the drain finished or was abandoned)
+//	Draining -> Stopped   (shutdown; final)
+//	Running  -> Stopped
+//	Paused   -> Stopped
+//
+// Stopped is final: nothing leaves it.
+type State int32
+
+const (
+	Running  State = iota // Accepting and Dispatching Work
+	Paused                // Accepting Work, not Dispatching it
+	Draining              // Finishing the Work in hand
+	Stopped               // Shut down: not Accepting Work
+)
+
+// String: Lower-case state name ("running", ...), as shown in Stats.
+func (s State) String() string {
+	switch s {
+	case Running:
+		return "running"
+	case Paused:
+		return "paused"
+	case Draining:
+		return "draining"
+	case Stopped:
+		return "stopped"
+	}
+	return "unknown"
+}
+
+// MarshalText: States appear by name in JSON.
+func (s State) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// State: The current lifecycle state. Safe from any goroutine; a
+// submitter or health check can use it to tell whether work is accepted.
+func (b *Balancer) State() State {
+	return State(b.state.Load())
+}
+
+// Transit: Moves the Balancer from state "from" to "to" in one atomic
+// step. Reports false (and changes nothing) if it was not in "from", so
+// racing transitions cannot both win.
+func (b *Balancer) transit(from, to State) bool {
+	return b.state.CompareAndSwap(int32(from), int32(to))
+}
@@ -0,0 +1,198 @@
+package balance
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// Stats Structure: A Snapshot of the balancing statistics.
+// The same numbers print() shows, in a form that is safe to hand to other
+// goroutines and to encode as JSON (field names are stable).
+type Stats struct {
+	Name       string        `json:"name,omitempty"` // Balancer Name
+	State      State         `json:"state"`          // Lifecycle State (running, paused, ...)
+	Workers    []WorkerStats `json:"workers"`        // Per Worker Values (by Worker ID)
+	Pending    int           `json:"pending"`        // Total Pending Load (Sum over Workers)
+	Average    float64       `json:"average"`        // Average Pending Count (per unit of Weight)
+	Variance   float64       `json:"variance"`       // Variance of Pending Counts (per unit of Weight)
+	Imbalance  float64       `json:"imbalance"`      // Std Deviation / Average (0 = Even)
+	Strategy   string        `json:"strategy"`       // Active Strategy Name
+	Size       int           `json:"size"`           // Current Worker Count
+	Capacity   int           `json:"capacity"`       // Most Workers the Pool may hold (0 = No Limit)
+	Minimum    int           `json:"minimum"`        // Fewest Workers the Pool may shrink to
+	Loop       Timing        `json:"loop"`           // Balance Loop Latency Summary
+	Totals     Totals        `json:"totals"`         // Cumulative Counters
+	Throughput Throughput    `json:"throughput"`     // Recent Rates (Sliding Window)
+	Deferred   int           `json:"deferred"`       // Deferred Requests not yet Dispatched
+	Memory     int64         `json:"result_memory"`  // Estimated Memory of Results not yet Taken
+	Active     int64         `json:"active"`         // Work Functions Executing right now
+	Dropped    int64         `json:"dropped_events"` // Completion Events lost (see OnCompletionEvent)
+}
+
+// Totals Structure: Cumulative counters since start (or the last
+// ResetStats), for measuring per-interval rates.
+type Totals struct {
+	Dispatched int64 `json:"dispatched"` // Requests sent to Workers
+	Completed  int64 `json:"completed"`  // Requests finished
+	Rejected   int64 `json:"rejected"`   // Requests refused admission
+	Stuck      int64 `json:"stuck"`      // Requests written off with a stuck Worker
+}
+
+// WorkerStats Structure: One Worker's entry in a Stats Snapshot
+type WorkerStats struct {
+	ID      int    `json:"id"`      // Stable Worker ID
+	Pending int    `json:"pending"` // Weighted Pending Load (Sum of Costs)
+	Jobs    int    `json:"jobs"`    // Pending Request Count
+	Weight  int    `json:"weight"`  // Relative Capacity (see SetWeight)
+	Queue   Timing `json:"queue"`   // Dispatch to Start (Waiting in the Buffer)
+	Service Timing `json:"service"` // Start to Completion (Executing)
+
+	Completed int64         `json:"completed"` // Jobs Completed since the Worker was Created
+	Uptime    time.Duration `json:"uptime_ns"` // Time since the Worker was Created
+	Rate      float64       `json:"rate"`      // Completed per Second of Uptime
+}
+
+// Timing Structure: A running summary of measured durations.
+// Used for the Balance Loop's time per event (from the event being
+// received to it being handled; a rising value while the Workers sit idle
+// means the single loop itself is the bottleneck) and for each Worker's
+// queue and service times.
+type Timing struct {
+	Count int64         `json:"count"`   // Durations Measured
+	Mean  time.Duration `json:"mean_ns"` // Average Duration
+	Max   time.Duration `json:"max_ns"`  // Longest Duration
+	total time.Duration // Sum of Durations (for the Mean)
+}
+
+// Record: Adds one duration; cheap enough for every event.
+func (t *Timing) record(d time.Duration) {
+	t.Count++
+	t.total += d
+	t.Mean = t.total / time.Duration(t.Count)
+	t.Max = max(t.Max, d)
+}
+
+// Publish: Builds a new Snapshot and makes it visible to Stats().
+// Only the Balance Loop touches the Pool, so only it may publish; readers
+// get an immutable copy through the atomic pointer and never race.
+func (b *Balancer) publish() {
+	s := &Stats{Workers: make([]WorkerStats, 0, b.pool.Len()), Strategy: b.strat.Name()}
+	now := time.Now()
+	for _, w := range b.byID() { // Stable Order: a Worker keeps its Slot
+		ws := WorkerStats{ID: w.id, Pending: w.pending, Jobs: len(w.jobs), Weight: w.weight, Queue: w.queued, Service: w.service}
+		ws.Completed = w.served
+		if !w.born.IsZero() { // Replayed Workers have no Uptime
+			ws.Uptime = now.Sub(w.born)
+			ws.Rate = float64(w.served) / ws.Uptime.Seconds()
+		}
+		s.Workers = append(s.Workers, ws)
+	}
+	s.Name = b.opts.name
+	s.State = b.State()
+	s.Pending = b.pending
+	s.Average, s.Variance = b.spread()
+	s.Imbalance = imbalance(s.Average, s.Variance)
+	s.Size, s.Capacity, s.Minimum = b.pool.Len(), b.max, b.min
+	s.Loop = b.loop
+	s.Totals = b.totals
+	s.Totals.Rejected = b.rejected.Load()
+	s.Throughput = b.rate.rates(time.Now())
+	s.Deferred = len(b.retry.queue) + len(b.retry.due)
+	s.Memory = b.mem.load()
+	s.Active = b.opts.active.Load()
+	s.Dropped = b.lost.Load()
+	b.stats.Store(s) // Swap in the new Snapshot
+}
+
+// ByID: The Pool's Workers sorted by stable ID. The Heap order changes on
+// every event and is nobody's business outside the Pool, so everything
+// presented to people (Stats, print) uses this order instead.
+func (b *Balancer) byID() []*Worker {
+	ws := slices.Clone(b.pool.Workers()) // Never reorder the Pool itself
+	slices.SortFunc(ws, func(x, y *Worker) int { return x.id - y.id })
+	return ws
+}
+
+// Imbalance: Coefficient of Variation (Standard Deviation / Average).
+// 0 means perfectly even; unlike the variance it does not grow with the
+// absolute load, so 0.5 means the same spread at any load. An idle Pool
+// counts as perfectly even.
+func imbalance(avg, variance float64) float64 {
+	if avg <= 0 {
+		return 0 // Idle: Nothing to Balance
+	}
+	return math.Sqrt(max(variance, 0)) / avg
+}
+
+// Stats: Returns the latest Snapshot. Safe from any goroutine.
+// The Snapshot must be treated as read-only.
+func (b *Balancer) Stats() *Stats {
+	return b.stats.Load()
+}
+
+// Size: Current number of Workers, as of the latest Snapshot.
+func (b *Balancer) Size() int {
+	return b.Stats().Size
+}
+
+// Load: Total pending load across the Pool as of the latest Snapshot
+// (which the Balance Loop refreshes after every dispatch and completion).
+// Safe from any goroutine.
+func (b *Balancer) Load() int {
+	return b.Stats().Pending
+}
+
+// Pending: Each Worker's pending load, in Worker ID order, read inside
+// the Balance Loop - exact as of every request already taken, unlike the
+// Snapshot, which may be one event behind. The Balance Loop must be
+// running.
+func (b *Balancer) Pending() []int {
+	var loads []int
+	b.do(func() {
+		for _, w := range b.byID() {
+			loads = append(loads, w.pending)
+		}
+	})
+	return loads
+}
+
+// Capacity: Most Workers the Pool may hold - the configured ceiling (see
+// SetPoolBounds), or 0 if there is none. Together with Size this tells
+// an autoscaler how much room is left. Safe from any goroutine.
+func (b *Balancer) Capacity() int {
+	return b.Stats().Capacity
+}
+
+// ResetStats: Zeroes the cumulative counters and the timing summaries,
+// leaving the live pending counts alone - snapshot, reset, repeat gives
+// per-interval figures. The reset runs inside the Balance Loop, so it
+// never races an update. The Balance Loop must be running.
+func (b *Balancer) ResetStats() {
+	b.do(func() {
+		b.totals = Totals{}
+		b.rejected.Store(0)
+		b.loop = Timing{}
+		b.peak, b.worst = b.pending, 0
+		b.rate.init(b.rate.width * meterBuckets)
+		for _, w := range b.workers {
+			w.queued, w.service = Timing{}, Timing{}
+		}
+	})
+}
+
+// StatsHandler: An http.Handler writing the current Snapshot as JSON,
+// ready to mount on a status endpoint.
+func (b *Balancer) StatsHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, err := json.Marshal(b.Stats())
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Write(body)
+	})
+}
@@ -0,0 +1,86 @@
+package balance
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStatsFromOtherGoroutines(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 4})
+	b.Start()
+	defer b.Close()
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ { // Readers racing the Balance Loop (go test -race)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				s := b.Stats()
+				sum := 0
+				for _, w := range s.Workers {
+					sum += w.Pending
+				}
+				if sum != s.Pending {
+					t.Errorf("snapshot pending %d, workers sum to %d", s.Pending, sum)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 200; i++ {
+		b.Fire(func() int { time.Sleep(50 * time.Microsecond); return 0 })
+	}
+	b.WaitIdle(context.Background())
+	close(stop)
+	wg.Wait()
+}
+
+func TestStatsSpread(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 2})
+	b.Start()
+	defer b.Close()
+	gate := make(chan struct{})
+	defer close(gate)
+	b.do(func() { // Pending [3 1]
+		for _, id := range []int{0, 0, 0, 1} {
+			b.dispatchTo(id, Request{fn: func() int { <-gate; return 0 }, c: make(chan int, 1)})
+		}
+	})
+	b.do(func() {}) // One more Event: the Snapshot is Published
+	s := b.Stats()
+	if s.Pending != 4 || s.Average != 2 || s.Variance != 1 || math.Abs(s.Imbalance-0.5) > 1e-9 {
+		t.Fatalf("pending %d, average %v, variance %v, imbalance %v; want 4, 2, 1, 0.5",
+			s.Pending, s.Average, s.Variance, s.Imbalance)
+	}
+}
+
+func TestWorkerTotals(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 2})
+	b.Start()
+	defer b.Close()
+	b.do(func() {
+		for _, id := range []int{0, 0, 0, 1} {
+			b.dispatchTo(id, Request{fn: func() int { return 0 }, c: make(chan int, 1)})
+		}
+	})
+	b.WaitIdle(context.Background())
+	time.Sleep(time.Millisecond) // Some Uptime
+	b.ResetStats()               // Lifetime Counts are not Reset
+	b.do(func() {})              // One more Event: the Snapshot is Published
+	for _, w := range b.Stats().Workers {
+		want := map[int]int64{0: 3, 1: 1}[w.ID]
+		if w.Completed != want || w.Uptime <= 0 || w.Rate <= 0 {
+			t.Fatalf("worker %d: completed %d, uptime %v, rate %v; want %d completed and both positive",
+				w.ID, w.Completed, w.Uptime, w.Rate, want)
+		}
+	}
+}
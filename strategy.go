@@ -0,0 +1,207 @@
+package balance
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Strategy: A Worker selection policy.
+// Name returns a stable identifier ("least-loaded", "round-robin", ...)
+// so logs and Stats show which policy a deployment is actually running.
+// Select picks the Worker for the next request from the whole Pool, given
+// in stable ID order; it reads each Worker through ID, Pending and
+// Weight. Select runs on the Balance Loop and may keep state between
+// calls without locking.
+type Strategy interface {
+	Name() string
+	Select(workers []*Worker) *Worker
+}
+
+// ID: The Worker's stable ID (as in Stats and the hooks).
+func (w *Worker) ID() int { return w.id }
+
+// Pending: The Worker's pending load - the summed cost of the requests
+// dispatched to it and not yet completed. Only meaningful on the Balance
+// Loop, i.e. inside a Strategy or Queue; elsewhere use Balancer.Pending.
+func (w *Worker) Pending() int { return w.pending }
+
+// Weight: The Worker's relative capacity (see SetWeight).
+func (w *Worker) Weight() int { return w.weight }
+
+// LeastLoaded: The classic policy - the Worker with the fewest pending
+// requests. The Balancer answers this one straight from its Queue (the
+// heap) instead of calling Select.
+type leastLoaded struct{}
+
+func (leastLoaded) Name() string { return "least-loaded" }
+
+func (leastLoaded) Select(workers []*Worker) *Worker {
+	best := workers[0]
+	for _, w := range workers[1:] {
+		if w.pending < best.pending {
+			best = w
+		}
+	}
+	return best
+}
+
+// LeastPending: The default Strategy, answered from the Pool's Heap; set
+// it to go back to least-loaded dispatch.
+var LeastPending Strategy = leastLoaded{}
+
+// RoundRobin: Each Worker in turn, in stable ID order, regardless of
+// load - a baseline to compare least-loaded dispatch against.
+type RoundRobin struct {
+	next int // Turn of the next Pick
+}
+
+// NewRoundRobin: Creates a RoundRobin Strategy.
+func NewRoundRobin() *RoundRobin {
+	return &RoundRobin{}
+}
+
+func (s *RoundRobin) Name() string { return "round-robin" }
+
+func (s *RoundRobin) Select(workers []*Worker) *Worker {
+	w := s.Peek(workers)
+	s.next++
+	return w
+}
+
+// Peek: The Worker whose turn is next (see Peeker).
+func (s *RoundRobin) Peek(workers []*Worker) *Worker {
+	return workers[s.next%len(workers)]
+}
+
+// Random: A Worker chosen uniformly at random, regardless of load. Peek
+// draws the next pick ahead of time and Select takes that same draw, so
+// Preview foresees the pick without changing the sequence (unless the
+// Pool changes size in between, which calls for a fresh draw).
+type Random struct {
+	r     *rand.Rand // Source of the Picks
+	ahead int        // Pick drawn by Peek (-1 = None)
+	size  int        // Pool Size it was drawn for
+}
+
+// NewRandom: Creates a Random Strategy drawing from "r"; nil means
+// time-seeded. A seeded source repeats the same picks.
+func NewRandom(r *rand.Rand) *Random {
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano())) // Unrepeatable Default
+	}
+	return &Random{r: r, ahead: -1}
+}
+
+func (s *Random) Name() string { return "random" }
+
+func (s *Random) Select(workers []*Worker) *Worker {
+	w := s.Peek(workers)
+	s.ahead = -1 // Draw Taken
+	return w
+}
+
+// Peek: The Worker the next Select will pick, drawn once and kept until
+// Select takes it (see Peeker).
+func (s *Random) Peek(workers []*Worker) *Worker {
+	if s.ahead < 0 || s.size != len(workers) {
+		s.ahead, s.size = s.r.Intn(len(workers)), len(workers)
+	}
+	return workers[s.ahead]
+}
+
+// WeightedRoundRobin: Predictable, low-overhead routing by weight.
+// A Worker of weight 3 gets three dispatches per cycle for every one a
+// weight 1 Worker gets, regardless of load. It uses the "smooth" weighted
+// round-robin algorithm (as in nginx): every pick, each Worker's credit
+// grows by its weight, the Worker with the most credit is chosen and pays
+// back the total weight. The picks for a heavy Worker are interleaved with
+// the others instead of arriving in one burst.
+type WeightedRoundRobin struct {
+	credit map[int]int // Current Credit by Worker ID
+}
+
+// NewWeightedRoundRobin: Creates a WeightedRoundRobin Strategy.
+func NewWeightedRoundRobin() *WeightedRoundRobin {
+	return &WeightedRoundRobin{credit: make(map[int]int)}
+}
+
+func (s *WeightedRoundRobin) Name() string { return "weighted-round-robin" }
+
+func (s *WeightedRoundRobin) Select(workers []*Worker) *Worker {
+	var best *Worker
+	total := 0
+	for _, w := range workers {
+		s.credit[w.id] += w.weight
+		total += w.weight
+		if best == nil || s.credit[w.id] > s.credit[best.id] {
+			best = w
+		}
+	}
+	s.credit[best.id] -= total // Chosen Worker pays back the Cycle
+	return best
+}
+
+// Peek: The Worker the next Select would pick, without advancing the
+// credits (see Peeker).
+func (s *WeightedRoundRobin) Peek(workers []*Worker) *Worker {
+	var best *Worker
+	for _, w := range workers {
+		if best == nil || s.credit[w.id]+w.weight > s.credit[best.id]+best.weight {
+			best = w
+		}
+	}
+	return best
+}
+
+// Peeker: Implemented by Strategies whose Select changes their own state
+// (like WeightedRoundRobin or Random), so Preview can ask for the next
+// pick without taking it. A Strategy without Peek is assumed to have a Select free of
+// side effects, which Preview then calls directly.
+type Peeker interface {
+	Peek(workers []*Worker) *Worker
+}
+
+// SetStrategy: Replaces the default least-loaded Strategy.
+// Must be called before the Balance Loop is started.
+func (b *Balancer) SetStrategy(s Strategy) {
+	b.strat = s
+}
+
+// StrategyName: Name of the active Strategy. Safe from any goroutine.
+func (b *Balancer) StrategyName() string {
+	return b.strat.Name()
+}
+
+// Peek: The Worker for the next request, per the Strategy, without
+// taking the pick (see take). A Strategy without Peek has already made
+// its pick here.
+func (b *Balancer) peek() *Worker {
+	switch s := b.strat.(type) {
+	case leastLoaded:
+		return b.pool.Least() // Same answer, straight from the Heap
+	case Peeker:
+		return s.Peek(b.workers)
+	default:
+		return s.Select(b.workers)
+	}
+}
+
+// Take: Lets the Strategy move on once the peeked Worker got the request.
+func (b *Balancer) take() {
+	if _, ok := b.strat.(Peeker); ok {
+		b.strat.Select(b.workers) // Same Worker as the Peek
+	}
+}
+
+// Preview: Which Worker would get a request submitted right now, and its
+// pending load - without dispatching anything, moving the Worker in the
+// Heap or advancing the Strategy. The answer is only momentary: other
+// submissions and completions may change it before the caller acts on
+// it. The Balance Loop must be running.
+func (b *Balancer) Preview() (workerID int, pending int) {
+	b.do(func() {
+		w := b.peek()
+		workerID, pending = w.id, w.pending
+	})
+	return workerID, pending
+}
@@ -0,0 +1,57 @@
+package balance_test
+
+import (
+	"testing"
+
+	"github.com/godfather667/balance"
+)
+
+// Lightest: A Strategy built outside the package - the least pending load
+// per unit of weight, ties going to the lowest ID.
+type lightest struct {
+	ids []int // Worker IDs as the first Select saw them
+}
+
+func (s *lightest) Name() string { return "lightest" }
+
+func (s *lightest) Select(workers []*balance.Worker) *balance.Worker {
+	if s.ids == nil {
+		for _, w := range workers {
+			s.ids = append(s.ids, w.ID())
+		}
+	}
+	best := workers[0]
+	for _, w := range workers[1:] {
+		if w.Pending()*best.Weight() < best.Pending()*w.Weight() {
+			best = w
+		}
+	}
+	return best
+}
+
+func TestExternalStrategy(t *testing.T) {
+	s := &lightest{}
+	b, err := balance.NewBalancerConfig(balance.Config{Workers: 3, Weights: []int{1, 2, 3}, Strategy: s})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Start()
+	defer b.Close()
+	gate := make(chan struct{})
+	defer close(gate)
+	for i := 0; i < 12; i++ {
+		b.Fire(func() int { <-gate; return 0 })
+	}
+	want := []int{2, 4, 6} // By Weight
+	for i, p := range b.Pending() {
+		if p != want[i] {
+			t.Fatalf("pending %v, want %v", b.Pending(), want)
+		}
+	}
+	if len(s.ids) != 3 || s.ids[0] != 0 || s.ids[1] != 1 || s.ids[2] != 2 {
+		t.Fatalf("Select saw worker IDs %v, want [0 1 2]", s.ids)
+	}
+	if b.StrategyName() != "lightest" {
+		t.Fatalf("strategy %q", b.StrategyName())
+	}
+}
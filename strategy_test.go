@@ -0,0 +1,57 @@
+package balance
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func TestWeightedRoundRobinRatios(t *testing.T) {
+	workers := []*Worker{{id: 0, weight: 3}, {id: 1, weight: 1}, {id: 2, weight: 2}}
+	s := NewWeightedRoundRobin()
+	for cycle := 0; cycle < 10; cycle++ {
+		count := map[int]int{}
+		var picks []int
+		for i := 0; i < 6; i++ { // One Cycle: the Total Weight
+			next := s.Peek(workers)
+			w := s.Select(workers)
+			if w != next {
+				t.Fatalf("Peek foresaw worker %d, Select picked %d", next.id, w.id)
+			}
+			count[w.id]++
+			picks = append(picks, w.id)
+		}
+		for _, w := range workers {
+			if count[w.id] != w.weight {
+				t.Fatalf("cycle %d: worker %d picked %d times, want its weight %d (picks %v)", cycle, w.id, count[w.id], w.weight, picks)
+			}
+		}
+		for i := 1; i < len(picks); i++ {
+			if picks[i] == 0 && picks[i-1] == 0 && i > 1 && picks[i-2] == 0 {
+				t.Fatalf("cycle %d: worker 0 got its whole share in one burst: %v", cycle, picks)
+			}
+		}
+	}
+}
+
+func TestRandomPreviewKeepsPick(t *testing.T) {
+	alone, _ := NewBalancerConfig(Config{Workers: 4, Strategy: NewRandom(rand.New(rand.NewSource(7)))})
+	peeked, _ := NewBalancerConfig(Config{Workers: 4, Strategy: NewRandom(rand.New(rand.NewSource(7)))})
+	alone.Start()
+	peeked.Start()
+	defer alone.Close()
+	defer peeked.Close()
+	for i := 0; i < 20; i++ {
+		want := alone.SubmitHandle(func() int { return 0 })
+		want.Wait(context.Background())
+		id, _ := peeked.Preview()
+		if again, _ := peeked.Preview(); again != id {
+			t.Fatalf("request %d: Preview said %d, then %d", i, id, again)
+		}
+		got := peeked.SubmitHandle(func() int { return 0 })
+		got.Wait(context.Background())
+		if got.Worker() != id || got.Worker() != want.Worker() {
+			t.Fatalf("request %d: previewed %d, ran on %d, without Preview %d", i, id, got.Worker(), want.Worker())
+		}
+	}
+}
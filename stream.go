@@ -0,0 +1,85 @@
+package balance
+
+import (
+	"context"
+	"sync"
+)
+
+// SubmitStream: Streaming Submission.
+// Every work function read from "in" is submitted to the Balancer and its
+// result is sent on the returned channel as soon as it completes (results
+// arrive in completion order, not input order). Submission blocks while
+// the Balance Loop is busy, so a slow Balancer pushes back on the reader
+// of "in". The output channel is closed once "in" is closed (or the
+// Balancer is) and every submitted function has reported.
+func (b *Balancer) SubmitStream(in <-chan func() int) <-chan int {
+	out := make(chan int)
+	go func() {
+		var wg sync.WaitGroup
+		for fn := range in { // Until the Input is closed
+			req := Request{fn: fn, c: b.replyChan()} // Request with its own Reply Channel
+			if !hand(b, b.work, req, nil) {
+				break // Closed: End the Stream
+			}
+			wg.Add(1)
+			go func() { // Forward the Reply
+				defer wg.Done()
+				out <- <-req.c
+			}()
+		}
+		wg.Wait() // All In-Flight Replies forwarded
+		close(out)
+	}()
+	return out
+}
+
+// StreamResult Structure: One item of a context-aware stream
+type StreamResult struct {
+	Index int   // Position of the Work Function in the input stream
+	Value int   // Result (0 if Err is set)
+	Err   error // Why there is no Result (the context's error), or nil
+}
+
+// SubmitStreamContext: SubmitStream with cancellation and per-item errors.
+// Results arrive in completion order, tagged with their input Index.
+// Cancelling "ctx" stops reading "in", cancels the in-flight items (each
+// is reported with the context's error) and closes the output once they
+// are all accounted for. Otherwise the output closes when "in" is closed
+// and every item has reported. Read the output until it is closed.
+func (b *Balancer) SubmitStreamContext(ctx context.Context, in <-chan func() int) <-chan StreamResult {
+	out := make(chan StreamResult)
+	go func() {
+		var wg sync.WaitGroup
+		defer func() {
+			wg.Wait() // All In-Flight Items reported
+			close(out)
+		}()
+		for i := 0; ; i++ {
+			var fn func() int
+			select {
+			case f, ok := <-in:
+				if !ok {
+					return // Input closed
+				}
+				fn = f
+			case <-ctx.Done():
+				return // Stop Dispatching
+			}
+			h := &Handle{done: make(chan struct{})}
+			h.worker.Store(-1) // Not Dispatched yet
+			if !hand(b, b.work, Request{fn: h.wrap(fn), c: make(chan int, 1), h: h}, ctx.Done()) {
+				return // Never Dispatched (Cancelled or Closed): not reported
+			}
+			wg.Add(1)
+			go func(i int) { // Report the Item
+				defer wg.Done()
+				v, err := h.Wait(ctx)
+				if err != nil {
+					h.Cancel() // Skip it if still Queued
+				}
+				out <- StreamResult{Index: i, Value: v, Err: err}
+			}(i)
+		}
+	}()
+	return out
+}
@@ -0,0 +1,74 @@
+package balance
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrStuck: The request's Worker was evicted as stuck while running it.
+var ErrStuck = errors.New("balance: worker stuck, request abandoned")
+
+// SetStuckTimeout: Evicts a Worker whose running request has been running
+// for longer than "d" (0, the default, never does). A work function that
+// blocks forever leaves its Worker looking busy but dead; the Balance
+// Loop checks on every heartbeat (once a second) and replaces such a
+// Worker with a fresh one (same weight and implementation, new stable
+// ID). The requests waiting in its buffer move to the other Workers. The
+// stuck request itself is written off: its load leaves the books (and is
+// counted in Totals.Stuck), and a submitter with a Handle or an error to
+// wait for (SubmitErr, SubmitTimeout, SubmitWithin, SubmitContext, ...)
+// gets ErrStuck. The submissions that have only a value to deliver -
+// Submit, SubmitAsync, Fire, Futures and the like - have no way to report
+// the eviction, and keep waiting for the result.
+// Should the function return after all, the old Worker delivers that
+// result, and its done report - for a request no longer on the books - is
+// ignored, so nothing is counted twice. Its goroutine then exits.
+// Must be called before the Balance Loop is started.
+func (b *Balancer) SetStuckTimeout(d time.Duration) {
+	b.hang = d
+}
+
+// Unstick: Evicts every stuck Worker. Returns how many were evicted.
+// Only called from the Balance Loop.
+func (b *Balancer) unstick() int {
+	if b.hang <= 0 {
+		return 0
+	}
+	var stuck []*Worker
+	for _, w := range b.workers {
+		if len(w.jobs) > 0 && !w.jobs[0].started.IsZero() && time.Since(w.jobs[0].started) > b.hang {
+			stuck = append(stuck, w) // Oldest Job is the Running one
+		}
+	}
+	for _, w := range stuck {
+		b.evict(w)
+	}
+	return len(stuck)
+}
+
+// Evict: Replaces a stuck Worker (see SetStuckTimeout). The replacement
+// joins first, so the Pool is never empty. Only called from the Balance
+// Loop.
+func (b *Balancer) evict(w *Worker) {
+	fresh := b.spawn(w.exec)
+	fresh.weight = w.weight
+	b.pool.Adjust(fresh) // Re-position for its Capacity
+	moved := b.withdraw(w)
+	for _, j := range w.jobs { // Left: the Stuck Job - Off the Books
+		w.pending -= j.cost
+		b.pending -= j.cost
+		b.totals.Stuck++
+		if j.h != nil {
+			j.h.finish(0, ErrStuck)
+		}
+	}
+	w.jobs = nil
+	w.stuck = true // Its late done Report is expected: Ignore it quietly
+	if b.onIdle != nil {
+		b.onIdle(w.id) // Nothing left on its Books
+	}
+	b.opts.logf("worker %d: stuck for over %v, replaced by worker %d", w.id, b.hang, fresh.id)
+	for _, req := range moved { // Re-dispatch in their Original Order
+		b.place(req)
+	}
+}
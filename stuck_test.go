@@ -0,0 +1,42 @@
+package balance
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStuckFailsErrorSubmitters(t *testing.T) {
+	for name, submit := range map[string]func(b *Balancer, fn func() int) error{
+		"SubmitTimeout": func(b *Balancer, fn func() int) error {
+			_, err := b.SubmitTimeout(time.Hour, fn)
+			return err
+		},
+		"SubmitWithin": func(b *Balancer, fn func() int) error {
+			_, err := b.SubmitWithin(time.Second, fn)
+			return err
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			b := NewBalancer()
+			b.SetStuckTimeout(10 * time.Millisecond)
+			b.Start()
+			defer b.Close()
+			release := make(chan struct{})
+			defer close(release)
+			out := make(chan error, 1)
+			go func() { out <- submit(b, func() int { <-release; return 1 }) }()
+			select {
+			case err := <-out:
+				if !errors.Is(err, ErrStuck) {
+					t.Fatalf("got %v, want ErrStuck", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("still waiting after the Worker was evicted")
+			}
+			if b.Stats().Totals.Stuck != 1 {
+				t.Fatalf("Totals.Stuck = %d, want 1", b.Stats().Totals.Stuck)
+			}
+		})
+	}
+}
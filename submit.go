@@ -0,0 +1,214 @@
+package balance
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrSaturated: The Balancer had no capacity for the request in time.
+var ErrSaturated = errors.New("balance: saturated")
+
+// Admit: The Admission Decision shared by the non-blocking submit paths.
+// The request is handed to the Balance Loop only if the loop can take it
+// right now; a saturated loop (busy dispatching into full worker buffers)
+// refuses it instead of making the caller wait.
+func (b *Balancer) admit(req Request) bool {
+	b.sending.Add(1) // Handing in (see hand)
+	defer b.sending.Add(-1)
+	if b.closed() {
+		return false // Closed: Nothing is Accepted
+	}
+	select {
+	case b.work <- req: // Balance Loop accepted the Request
+		return true
+	default: // Saturated: Refuse
+		b.rejected.Add(1)
+		return false
+	}
+}
+
+// Submit: Runs "fn" on the least loaded Worker and waits for its result.
+// The Balance Loop must be running (see New and Start).
+func (b *Balancer) Submit(fn func() int) int {
+	return b.run(fn)
+}
+
+// TrySubmit: Fail-fast Submission. If the Balancer can take the request
+// right now - the Balance Loop is ready for it or the intake buffer (see
+// SetIntakeBuffer) has room - it is submitted and its result returned
+// with true; otherwise nothing is run and TrySubmit returns false at
+// once. It shares the intake with Submit, so the two mix freely.
+func (b *Balancer) TrySubmit(fn func() int) (int, bool) {
+	req := Request{fn: fn, c: b.replyChan()} // Request with its own Reply Channel
+	if !b.admit(req) {
+		return 0, false // Overloaded: Give up
+	}
+	return <-req.c, true // Wait for "Done" Reply
+}
+
+// SubmitErr: Submit for work that can fail. The result and the error of
+// "fn" both come back to the caller; to the Balancer an error is just a
+// result, so the Worker reports done and its pending count is released as
+// for any other request. A failed request is retried if SetRetry says so.
+// If "fn" panics and the panic is recovered (see PanicPolicy) a
+// PanicError carrying the panic value is returned.
+func (b *Balancer) SubmitErr(fn func() (int, error)) (int, error) {
+	v, err := b.attempt(fn, 0)
+	for n := 1; n < b.retries.MaxAttempts && retryable(err); n++ {
+		v, err = b.attempt(fn, b.retries.Backoff) // Retry: Possibly another Worker
+	}
+	return v, err
+}
+
+// Attempt: Runs "fn" once for SubmitErr, after "delay" (0 = Submitted at
+// once, otherwise held as deferred work), and waits for its outcome.
+func (b *Balancer) attempt(fn func() (int, error), delay time.Duration) (int, error) {
+	var err error
+	h := &Handle{done: make(chan struct{})} // Only told of a Refusal, Eviction or Close
+	h.worker.Store(-1)
+	req := Request{fn: func() int {
+		defer catch(func(e error) { err = e })
+		v, e := fn()
+		err = e // Written before the Reply: Read safely below
+		return v
+	}, c: b.replyChan(), h: h}
+	sent := false
+	if delay > 0 {
+		sent = hand(b, b.retry.in, delayed{time.Now().Add(delay), req}, nil) // Hand to the Balance Loop
+	} else {
+		sent = hand(b, b.work, req, nil) // Push Request into "Work" Channel
+	}
+	if !sent {
+		return 0, ErrClosed
+	}
+	var v int
+	select {
+	case v = <-req.c: // Wait for "Done" Reply
+	case <-h.done: // No Reply is coming
+	}
+	if h.err != nil {
+		return 0, h.err // Refused (see SetMaxPending), Stuck (see SetStuckTimeout) or Closed
+	}
+	return v, err
+}
+
+// SubmitOrElse: Submit with Graceful Degradation.
+// If the Balancer has capacity "fn" is dispatched and its result returned,
+// otherwise "fallback" is run on the calling goroutine (serve stale data,
+// compute inline, ...) and its result is returned instead.
+func (b *Balancer) SubmitOrElse(fn func() int, fallback func() int) int {
+	req := Request{fn: fn, c: b.replyChan()} // Request with its own Reply Channel
+	if !b.admit(req) {
+		return fallback() // No Capacity: Degrade
+	}
+	return <-req.c // Wait for "Done" Reply
+}
+
+// SubmitCost: Submits "fn" with an estimated cost and waits for its
+// result. A request of cost 5 counts as five pending requests on its
+// Worker until it completes, steering other work elsewhere meanwhile.
+// A cost below 1 counts as 1.
+func (b *Balancer) SubmitCost(cost int, fn func() int) int {
+	req := Request{fn: fn, c: b.replyChan(), cost: cost}
+	b.send(req)    // Push Request into "Work" Channel
+	return <-req.c // Wait for "Done" Reply
+}
+
+// SubmitWithin: Bounded-wait Submission.
+// Waits up to "d" for the Balancer to become Ready (see Ready) and take
+// the request, then waits for the result. If the request could not be
+// admitted in time it is never run and ErrSaturated is returned (after
+// Close, ErrClosed). Only the admission is bounded; once admitted the
+// result is always waited for, and any error of the request itself
+// (PanicError, ErrStuck, ...) is returned.
+func (b *Balancer) SubmitWithin(d time.Duration, fn func() int) (int, error) {
+	h := &Handle{done: make(chan struct{})}
+	h.worker.Store(-1)                                         // Not Dispatched yet
+	req := Request{fn: h.wrap(fn), c: make(chan int, 1), h: h} // Reply is never read: buffer it
+	if err := b.within(d, req); err != nil {
+		return 0, err
+	}
+	<-h.done // Wait for the Result (or a Refusal or Eviction)
+	return h.val, h.err
+}
+
+// Within: The bounded admission of SubmitWithin, as a hand (see hand).
+func (b *Balancer) within(d time.Duration, req Request) error {
+	b.sending.Add(1)
+	defer b.sending.Add(-1)
+	if b.closed() {
+		return ErrClosed
+	}
+	select {
+	case b.work <- req: // Fast Path: Capacity right now
+		return nil
+	default:
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-b.Ready(): // Capacity Signalled
+	case <-b.closing:
+		return ErrClosed
+	case <-t.C:
+		b.rejected.Add(1)
+		return ErrSaturated
+	}
+	select {
+	case b.work <- req: // Balance Loop accepted the Request
+		return nil
+	case <-b.closing:
+		return ErrClosed
+	case <-t.C:
+		b.rejected.Add(1)
+		return ErrSaturated
+	}
+}
+
+// SubmitBroadcast: Runs "fn" once and delivers its result to "n"
+// consumers, one channel each, registered up front. Each channel is
+// buffered, so every consumer is served even if some never read, and the
+// Worker is never held up by a slow one. If "fn" panics (and is recovered,
+// see PanicPolicy) every consumer receives 0, as with Submit.
+func (b *Balancer) SubmitBroadcast(n int, fn func() int) []<-chan int {
+	outs := make([]chan int, n)
+	recv := make([]<-chan int, n) // Receive-only view for the Consumers
+	for i := range outs {
+		outs[i] = make(chan int, 1)
+		recv[i] = outs[i]
+	}
+	b.send(Request{fn: func() (v int) { // Push Request into "Work" Channel
+		defer func() { // Even if it Panics: a Zero Result
+			for _, c := range outs { // Fan the Result out
+				c <- v
+			}
+		}()
+		return fn()
+	}, c: make(chan int, 1)}) // Reply is never read: buffer it
+	return recv
+}
+
+// Fire: Fire-and-forget Submission, for work run purely for its side
+// effects. The result is dropped into a one-slot buffer nobody reads, so
+// the Worker never waits for a reader and the request completes (and its
+// pending count is released) like any other. Blocks only while the
+// Balance Loop takes the request.
+func (b *Balancer) Fire(fn func() int) {
+	b.send(Request{fn: fn, c: make(chan int, 1)}) // Reply is never read: buffer it
+}
+
+// SubmitAsync: Submits "fn" and returns its reply channel without
+// waiting, so one goroutine can have many requests in flight and collect
+// the results later. The channel receives exactly one value, the result,
+// and is buffered: the Worker never waits for the caller, who may read
+// late or not at all. Until then len() of the channel is 0, so it also
+// tells whether the request is done. Blocks only while the Balance Loop
+// takes the request - which it does not while every buffer it could
+// dispatch to is full, so a flood of submissions is held back at the
+// intake instead of piling up (see SubmitFuture for a result with an
+// error).
+func (b *Balancer) SubmitAsync(fn func() int) <-chan int {
+	req := Request{fn: fn, c: make(chan int, 1)} // Buffered Reply Channel
+	b.send(req)                                  // Push Request into "Work" Channel
+	return req.c
+}
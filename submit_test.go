@@ -0,0 +1,42 @@
+package balance
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSubmitAsyncBackpressure(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 2, QueueDepth: 2})
+	b.Start()
+	gate := make(chan struct{})
+	var in atomic.Int64 // SubmitAsync calls returned
+	replies := make(chan (<-chan int), 20)
+	go func() {
+		for i := 0; i < 20; i++ {
+			replies <- b.SubmitAsync(func() int { <-gate; return 1 })
+			in.Add(1)
+		}
+		close(replies)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	// Two running, two buffered on each Worker, one waiting for a slot:
+	// the next submitter is held back at the intake.
+	if n := in.Load(); n != 7 {
+		t.Errorf("%d submissions taken in with every buffer full, want 7", n)
+	}
+	close(gate)
+	sum := 0
+	for c := range replies {
+		select {
+		case v := <-c:
+			sum += v
+		case <-time.After(5 * time.Second):
+			t.Fatal("request never answered")
+		}
+	}
+	if sum != 20 {
+		t.Fatalf("%d of 20 requests answered", sum)
+	}
+	b.Close()
+}
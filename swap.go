@@ -0,0 +1,69 @@
+package balance
+
+import "time"
+
+// Executor: A Worker implementation - how a Worker runs the work functions
+// it is given, e.g. through a client or connection of its own. It must
+// call "fn" (or do its equivalent) and return the result. Workers created
+// by the Balancer have none and call the functions directly.
+type Executor func(fn func() int) int
+
+// SwapWorkers: A blue/green swap of the whole Pool, for deploying a new
+// Worker implementation inside a running process. In one step of the
+// Balance Loop it adds "count" new Workers (with fresh stable IDs), built
+// by "factory", and retires every old one: the requests waiting in the
+// old Workers' buffers move to the new Workers in their original order,
+// and no new request reaches an old Worker. The Pool is therefore never
+// empty and the pending counts stay exact - requests are moved, never
+// dropped or counted twice. SwapWorkers then waits until the old Workers
+// have finished the requests they were running, and returns the IDs of
+// the new Workers (nil, and nothing changed, if "count" is below 1 or
+// outside the Pool's bounds, see SetPoolBounds).
+// The Balance Loop must be running.
+func (b *Balancer) SwapWorkers(factory func(id int) Executor, count int) []int {
+	if count < 1 || count < b.min || b.max > 0 && count > b.max {
+		return nil // Within the Bounds (see SetPoolBounds), never Empty
+	}
+	var ids []int
+	var old []*Worker
+	b.do(func() {
+		old = b.workers
+		for range count { // Blue: Bring up the new Workers
+			w := b.spawn(factory(b.ids))
+			ids = append(ids, w.id)
+		}
+		var moved []Request
+		for _, w := range old { // Green: Retire the old ones
+			moved = append(moved, b.withdraw(w)...)
+		}
+		for _, req := range moved { // Only the new Workers remain
+			b.place(req)
+		}
+	})
+	t := time.NewTicker(drainTick)
+	defer t.Stop()
+	for { // Wait for the old Workers to Finish their Running Requests
+		busy := false
+		b.do(func() {
+			for _, w := range old {
+				busy = busy || w.pending > 0
+			}
+		})
+		if !busy {
+			return ids
+		}
+		<-t.C
+	}
+}
+
+// Spawn: Creates a Worker with the next stable ID, adds it to the Pool
+// and the roster, and starts it. Only called from the Balance Loop.
+func (b *Balancer) spawn(exec Executor) *Worker {
+	w := &Worker{id: b.ids, requests: make(chan Request, b.depth), weight: 1, opts: &b.opts, begin: b.begin, exec: exec, born: time.Now()}
+	b.ids++
+	b.pool.Add(w)
+	b.workers = append(b.workers[:len(b.workers):len(b.workers)], w) // Copy on Write (see enroll)
+	b.enroll()
+	go w.work(b.done)
+	return w
+}
@@ -0,0 +1,56 @@
+package balance
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSwapWorkersUnderLoad(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 3})
+	b.Start()
+	defer b.Close()
+	var green atomic.Int64 // Requests run by the new Implementation
+	factory := func(id int) Executor {
+		return func(fn func() int) int { green.Add(1); return fn() }
+	}
+	const submitters, each = 4, 50
+	var wg sync.WaitGroup
+	var bad atomic.Int64
+	for g := 0; g < submitters; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < each; i++ {
+				v, err := b.SubmitHandle(func() int { time.Sleep(100 * time.Microsecond); return i }).Wait(context.Background())
+				if err != nil || v != i {
+					bad.Add(1)
+				}
+			}
+		}()
+	}
+	time.Sleep(5 * time.Millisecond) // Swap mid-stream
+	ids := b.SwapWorkers(factory, 2)
+	wg.Wait()
+	b.WaitIdle(context.Background())
+	if bad.Load() != 0 {
+		t.Fatalf("%d requests lost or wrong across the swap", bad.Load())
+	}
+	if len(ids) != 2 || b.Size() != 2 {
+		t.Fatalf("new IDs %v, pool size %d; want 2 new Workers only", ids, b.Size())
+	}
+	if n := b.Report().Processed; n != submitters*each {
+		t.Fatalf("%d requests completed, want %d", n, submitters*each)
+	}
+	if green.Load() == 0 {
+		t.Fatal("no request ran on the new Workers")
+	}
+	h := b.SubmitHandle(func() int { return 0 })
+	h.Wait(context.Background())
+	if !slices.Contains(ids, h.Worker()) {
+		t.Fatalf("request after the swap ran on worker %d, not a new one %v", h.Worker(), ids)
+	}
+}
@@ -0,0 +1,81 @@
+package balance
+
+import "time"
+
+// Default Throughput Window: Long enough to smooth bursts, short enough to
+// follow a change in load within seconds.
+const throughputWindow = 10 * time.Second
+
+// meterBuckets: Buckets per Window (the resolution of the slide)
+const meterBuckets = 10
+
+// Throughput Structure: Recent request rates, per second, over the Window
+// ending at the Snapshot. The current bucket is still filling, so a
+// sudden stop shows up gradually over one Window.
+type Throughput struct {
+	Window     time.Duration `json:"window_ns"`  // Span the Rates cover
+	Dispatched float64       `json:"dispatched"` // Requests sent to Workers per Second
+	Completed  float64       `json:"completed"`  // Requests finished per Second
+}
+
+// Meter: A ring of time buckets counting dispatches and completions.
+// Buckets are keyed by their epoch (time / bucket width), so rotating is
+// just noticing a bucket is stale and zeroing it - no timer, no lock, as
+// only the Balance Loop counts and reads.
+type meter struct {
+	width time.Duration // Span of one Bucket
+	ring  []tally       // Buckets, indexed by epoch modulo length
+}
+
+type tally struct {
+	epoch      int64 // Bucket Number this Tally belongs to
+	dispatched int64
+	completed  int64
+}
+
+func (m *meter) init(window time.Duration) {
+	m.width = max(window/meterBuckets, time.Millisecond)
+	m.ring = make([]tally, meterBuckets)
+}
+
+// Bucket: The current bucket, cleared first if it holds an old epoch.
+func (m *meter) bucket(now time.Time) *tally {
+	e := now.UnixNano() / int64(m.width)
+	t := &m.ring[e%int64(len(m.ring))]
+	if t.epoch != e {
+		*t = tally{epoch: e} // Rotate: Stale Bucket reused
+	}
+	return t
+}
+
+func (m *meter) dispatched(now time.Time) { m.bucket(now).dispatched++ }
+func (m *meter) completed(now time.Time)  { m.bucket(now).completed++ }
+
+// Rates: Sums the buckets still inside the Window.
+func (m *meter) rates(now time.Time) Throughput {
+	e := now.UnixNano() / int64(m.width)
+	window := m.width * time.Duration(len(m.ring))
+	t := Throughput{Window: window}
+	for _, b := range m.ring {
+		if b.epoch > e-int64(len(m.ring)) && b.epoch <= e { // Within the Window
+			t.Dispatched += float64(b.dispatched)
+			t.Completed += float64(b.completed)
+		}
+	}
+	t.Dispatched /= window.Seconds()
+	t.Completed /= window.Seconds()
+	return t
+}
+
+// SetThroughputWindow: Sets the span the Throughput rates are measured
+// over (default 10s). The counts so far are discarded. Must be called
+// before the Balance Loop is started.
+func (b *Balancer) SetThroughputWindow(d time.Duration) {
+	b.rate.init(d)
+}
+
+// Throughput: Recent dispatch and completion rates, as of the latest
+// Snapshot. Safe from any goroutine.
+func (b *Balancer) Throughput() Throughput {
+	return b.Stats().Throughput
+}
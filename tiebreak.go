@@ -0,0 +1,23 @@
+package balance
+
+// TieBreak: How the Heap orders Workers with equal pending counts.
+type TieBreak int32
+
+const (
+	// TieLeastRecent: The equally loaded Worker that was dispatched to
+	// longest ago. Under an even load this rotates through the Workers in
+	// turn, i.e. round-robin among the equals.
+	TieLeastRecent TieBreak = iota
+	// TieAny: Whichever the Heap happens to hold first. Depends on earlier
+	// swaps, and over time tends to favour the low Pool positions.
+	TieAny
+)
+
+// SetTieBreak: Chooses how equally loaded Workers are ordered. The default
+// is TieLeastRecent, so a light load is spread over the Workers in turn
+// rather than worn into the first few. Only the Heap honours it (Buckets keep their own order), and
+// it must be set before the Balance Loop is started, as changing the order
+// under a live Heap would corrupt it.
+func (b *Balancer) SetTieBreak(t TieBreak) {
+	b.opts.tie.Store(int32(t))
+}
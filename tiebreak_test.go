@@ -0,0 +1,61 @@
+package balance
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Rotation: The Workers "n" requests, submitted one at a time into an
+// idle Pool, went to.
+func rotation(b *Balancer, n int) []int {
+	ctx := context.Background()
+	var ids []int
+	for i := 0; i < n; i++ {
+		h := b.SubmitHandle(func() int { return 0 })
+		h.Wait(ctx)
+		for sum(b.Pending()) > 0 { // Every Worker equally loaded again
+			time.Sleep(time.Millisecond)
+		}
+		ids = append(ids, h.Worker())
+	}
+	return ids
+}
+
+func sum(xs []int) int {
+	n := 0
+	for _, x := range xs {
+		n += x
+	}
+	return n
+}
+
+func TestTieBreakRotates(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 3})
+	b.Start()
+	defer b.Close()
+	ids := rotation(b, 9)
+	if ids[0] == ids[1] || ids[1] == ids[2] || ids[0] == ids[2] {
+		t.Fatalf("first round %v did not visit every Worker", ids[:3])
+	}
+	for i, id := range ids {
+		if id != ids[i%3] {
+			t.Fatalf("dispatches %v do not rotate round-robin", ids)
+		}
+	}
+}
+
+func TestTieBreakEvenSpread(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 5})
+	b.Start()
+	defer b.Close()
+	count := map[int]int{}
+	for _, id := range rotation(b, 50) {
+		count[id]++
+	}
+	for id := 0; id < 5; id++ {
+		if count[id] != 10 {
+			t.Fatalf("equal-load dispatches spread %v, want 10 each", count)
+		}
+	}
+}
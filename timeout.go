@@ -0,0 +1,67 @@
+package balance
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTimeout: The work function did not finish within its time limit.
+var ErrTimeout = errors.New("balance: work function timed out")
+
+// SubmitTimeout: Submit with a limit on how long the work may run. Go
+// cannot stop a running function, so "fn" runs on a goroutine of its own
+// while the Worker waits at most "d" for it (from when it starts, not
+// from submission). If "fn" is late ErrTimeout is returned and the Worker
+// is freed at once: it reports the request done, so its pending count is
+// released exactly once, and takes its next request. The abandoned "fn"
+// runs on to the end unseen - its result goes to a buffer nobody reads
+// and it never touches the Balancer's books - but it still holds whatever
+// it was using, and it no longer counts against SetExecutionLimit.
+// A panic in "fn" is handled by the PanicPolicy as usual if it comes in
+// time (and, if recovered, returned as a PanicError); one after the
+// timeout is logged and dropped. Like any request with an error to report
+// it gets ErrStuck if its Worker is evicted (see SetStuckTimeout).
+func (b *Balancer) SubmitTimeout(d time.Duration, fn func() int) (int, error) {
+	var err error // Written before the Handle is done: Read safely below
+	h := b.SubmitHandle(func() int {
+		type outcome struct {
+			v int
+			p interface{} // Recovered Panic (nil = None)
+		}
+		res := make(chan outcome, 1) // Buffered: an Abandoned "fn" never blocks
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					res <- outcome{p: p}
+				}
+			}()
+			res <- outcome{v: fn()}
+		}()
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case o := <-res:
+			if o.p != nil {
+				err = &PanicError{Value: o.p}
+				panic(o.p) // On the Worker: Subject to the PanicPolicy
+			}
+			return o.v
+		case <-t.C:
+			err = ErrTimeout
+			go func() { // Watch the Abandoned "fn" for a late Panic
+				if o := <-res; o.p != nil {
+					b.opts.logf("timed-out work function panicked: %v", o.p)
+				}
+			}()
+			return 0
+		}
+	})
+	<-h.done // Wait for the Result (or a Refusal, Eviction or Close)
+	if h.err != nil {
+		return 0, h.err
+	}
+	if err != nil {
+		return 0, err
+	}
+	return h.val, nil
+}
@@ -0,0 +1,74 @@
+package balance
+
+import "time"
+
+// TraceKind: What happened in a TraceEvent
+type TraceKind int
+
+const (
+	Dispatched TraceKind = iota // Request sent to a Worker
+	Completed                   // Worker finished a Request
+)
+
+// TraceEvent Structure: One dispatch or completion, in Balance Loop order
+type TraceEvent struct {
+	Kind   TraceKind `json:"kind"`
+	Seq    int64     `json:"seq"`    // Request Number (matches its two events)
+	Worker int       `json:"worker"` // Stable ID of the Worker involved
+	Cost   int       `json:"cost"`   // Load of the Request
+	At     time.Time `json:"at"`     // When the Balance Loop saw it
+}
+
+// OnTrace: Installs a recorder called for every dispatch and completion,
+// in the exact order the Balance Loop handled them. It runs on the loop,
+// so it should just append the event somewhere. Must be called before the
+// Balance Loop is started.
+func (b *Balancer) OnTrace(fn func(TraceEvent)) {
+	b.onTrace = fn
+}
+
+// Trace: Reports one event to the recorder, if any.
+func (b *Balancer) trace(kind TraceKind, w *Worker, j job) {
+	if b.onTrace != nil {
+		b.onTrace(TraceEvent{Kind: kind, Seq: j.seq, Worker: w.id, Cost: j.cost, At: time.Now()})
+	}
+}
+
+// ReplayTrace: Re-runs a recorded trace against a fresh Pool and returns
+// the final statistics, turning a reported imbalance into a repeatable test.
+//
+// Only the ORDER of events is replayed. Dispatches go through the normal
+// least-loaded selection (so a fixed selection bug shows up as a different
+// distribution), and each completion retires the replayed copy of the same
+// request, wherever it was placed. Everything tied to real time is dropped:
+// timestamps, work functions (nothing runs), cooldowns and panics.
+// Completions for requests dispatched before the trace began are skipped.
+func ReplayTrace(events []TraceEvent) *Stats {
+	n := nWorker // Pool as large as the recorded one
+	for _, ev := range events {
+		n = max(n, ev.Worker+1)
+	}
+	pool := make(Pool, 0, n)
+	b := &Balancer{pool: &pool, strat: leastLoaded{}, max: n}
+	b.rate.init(throughputWindow)
+	for i := 0; i < n; i++ { // Workers without goroutines: nothing runs
+		w := &Worker{id: i, requests: make(chan Request, len(events)), weight: 1, opts: &b.opts}
+		b.pool.Add(w)
+		b.workers = append(b.workers, w)
+	}
+	where := make(map[int64]finished) // Recorded Request -> Replayed Copy
+	for _, ev := range events {
+		switch ev.Kind {
+		case Dispatched:
+			w := b.dispatch(Request{cost: ev.Cost})
+			where[ev.Seq] = finished{w, b.seq}
+		case Completed:
+			if f, ok := where[ev.Seq]; ok {
+				b.completed(f.w, f.seq)
+				delete(where, ev.Seq)
+			}
+		}
+	}
+	b.publish()
+	return b.Stats()
+}
@@ -0,0 +1,70 @@
+package balance
+
+// Typed: A Balancer front for work functions returning any result type,
+// such as strings or structs, instead of the int the core passes around.
+// A result goes from the work function straight to its submitter (the
+// Balance Loop only ever sees the int placeholder), so no data has to be
+// smuggled through closures. Everything else - Stats, Close, the
+// settings - is the embedded Balancer's.
+//
+//	b := balance.NewTyped[string]()
+//	s := b.Submit(func() string { return "done" })
+//
+// NewTypedConfig and TypedFrom build one on a configured Balancer.
+type Typed[T any] struct {
+	*Balancer
+}
+
+// NewTyped: Creates a Typed Balancer with the default settings and starts
+// its Balance Loop. Use NewTypedConfig or TypedFrom to configure it first.
+func NewTyped[T any]() *Typed[T] {
+	return &Typed[T]{New()}
+}
+
+// NewTypedConfig: Creates a Typed Balancer sized and set up from "cfg", as
+// NewBalancerConfig. The Balance Loop is not started, so the remaining
+// settings (SetQueue, SetScheduler, ...) can be made first; then Start it.
+func NewTypedConfig[T any](cfg Config) (*Typed[T], error) {
+	b, err := NewBalancerConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Typed[T]{b}, nil
+}
+
+// TypedFrom: A Typed front for an existing Balancer, started or not.
+// Fronts of different result types may share one Balancer, and so its
+// Workers and Stats.
+func TypedFrom[T any](b *Balancer) *Typed[T] {
+	return &Typed[T]{b}
+}
+
+// Submit: Runs "fn" on the least loaded Worker and waits for its result.
+// If "fn" panics and the panic is recovered (see PanicPolicy) the zero
+// value is returned.
+func (t *Typed[T]) Submit(fn func() T) T {
+	var v T
+	t.run(func() int {
+		v = fn() // Written before the Reply: Read safely below
+		return 0
+	})
+	return v
+}
+
+// SubmitFuture: Submits "fn" and returns its Future without waiting.
+// If "fn" panics and the panic is recovered the Future reports a
+// PanicError (matching ErrPanicked).
+func (t *Typed[T]) SubmitFuture(fn func() T) *Future[T] {
+	f := newFuture[T]()
+	var zero T
+	req := Request{fn: func() int {
+		defer catch(func(err error) { f.resolve(zero, err) }) // Panicking: Release the Waiters
+		v := fn()
+		f.resolve(v, nil)
+		return 0
+	}, c: make(chan int, 1)} // Reply is never read: buffer it
+	if !hand(t.Balancer, t.work, req, nil) {
+		f.resolve(zero, ErrClosed) // Never Submitted
+	}
+	return f
+}
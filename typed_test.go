@@ -0,0 +1,43 @@
+package balance
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTypedConfig(t *testing.T) {
+	b, err := NewTypedConfig[string](Config{Workers: 2, Strategy: NewRoundRobin()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.SetQueue(NewBuckets()) // Not yet Started: Still Configurable
+	b.Start()
+	defer b.Close()
+	if s := b.Submit(func() string { return "done" }); s != "done" {
+		t.Fatalf("Submit: %q", s)
+	}
+	if b.StrategyName() != "round-robin" || b.Size() != 2 {
+		t.Fatalf("strategy %q, size %d; want the configured round-robin and 2", b.StrategyName(), b.Size())
+	}
+	if _, err := NewTypedConfig[string](Config{}); err == nil {
+		t.Fatal("a Pool without Workers accepted")
+	}
+}
+
+func TestTypedFromShares(t *testing.T) {
+	type point struct{ x, y int }
+	b := New()
+	defer b.Close()
+	words, points := TypedFrom[string](b), TypedFrom[point](b)
+	if s := words.Submit(func() string { return "hi" }); s != "hi" {
+		t.Fatalf("string front: %q", s)
+	}
+	p, err := points.SubmitFuture(func() point { return point{1, 2} }).Get(context.Background())
+	if err != nil || p != (point{1, 2}) {
+		t.Fatalf("struct front: %v, %v", p, err)
+	}
+	b.WaitIdle(context.Background())
+	if n := b.Stats().Totals.Completed; n != 2 {
+		t.Fatalf("%d completions on the shared Balancer, want 2", n)
+	}
+}
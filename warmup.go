@@ -0,0 +1,63 @@
+package balance
+
+import (
+	"context"
+	"time"
+)
+
+// Warmup: Runs "fn" once on every Worker before real traffic arrives, so
+// per-worker lazy initialization (connections, caches, ...) is paid up
+// front rather than by the first unlucky requests. A nil "fn" sends a
+// no-op, which still walks each Worker through one full request.
+// A Worker whose buffer is full gets its warmup request once it has room.
+// Waits until every Worker has finished its warmup request or "ctx" is
+// done (the warmup requests still run; only the wait is cut short).
+// After Close it returns ErrClosed. The Balance Loop must be running.
+func (b *Balancer) Warmup(ctx context.Context, fn func() int) error {
+	if fn == nil {
+		fn = func() int { return 0 } // No-op Warmup
+	}
+	var replies []chan int
+	var cold []*Worker // Not yet Sent their Warmup
+	if !b.do(func() { cold = b.byID() }) {
+		return ErrClosed
+	}
+	t := time.NewTicker(drainTick)
+	defer t.Stop()
+	for len(cold) > 0 {
+		if !b.do(func() {
+			var full []*Worker
+			for _, w := range cold { // Every Worker, Regardless of Load
+				if w.retired {
+					continue // Removed meanwhile: Nothing to Warm
+				}
+				if !w.spare() {
+					full = append(full, w) // Try again once it has Room
+					continue
+				}
+				c := make(chan int, 1) // Buffered: Abandoning the Wait never blocks a Worker
+				b.assign(w, Request{fn: fn, c: c})
+				b.pool.Adjust(w)
+				replies = append(replies, c)
+			}
+			cold = full
+		}) {
+			return ErrClosed
+		}
+		if len(cold) > 0 {
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	for _, c := range replies { // Wait for Each Worker
+		select {
+		case <-c:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
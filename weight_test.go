@@ -0,0 +1,38 @@
+package balance
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+// Spread: The pending loads after "n" requests held on "gate".
+func spread(b *Balancer, n int, gate chan struct{}) []int {
+	for i := 0; i < n; i++ {
+		b.Fire(func() int { <-gate; return 0 })
+	}
+	return b.Pending()
+}
+
+func TestSetWorkerWeightShiftsDispatch(t *testing.T) {
+	b, _ := NewBalancerConfig(Config{Workers: 2})
+	b.Start()
+	defer b.Close()
+	gate := make(chan struct{})
+	if got := spread(b, 8, gate); !slices.Equal(got, []int{4, 4}) {
+		t.Fatalf("equal weights: pending %v, want [4 4]", got)
+	}
+	close(gate)
+	b.WaitIdle(context.Background())
+	if !b.SetWorkerWeight(0, 3) {
+		t.Fatal("SetWorkerWeight refused worker 0")
+	}
+	gate = make(chan struct{})
+	defer close(gate)
+	if got := spread(b, 8, gate); !slices.Equal(got, []int{6, 2}) {
+		t.Fatalf("weights 3:1: pending %v, want [6 2]", got)
+	}
+	if b.SetWorkerWeight(99, 2) || b.SetWorkerWeight(1, 0) {
+		t.Fatal("SetWorkerWeight accepted an unknown Worker or a weight below 1")
+	}
+}